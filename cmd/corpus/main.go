@@ -0,0 +1,62 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Rishav176/GitReviewed/internal/scanner"
+)
+
+func main() {
+	corpusFile := flag.String("corpus", "configs/corpus.yaml", "YAML corpus of labeled lines")
+	patternsFile := flag.String("patterns", "", "YAML file with additional secret patterns")
+	minPrecision := flag.Float64("min-precision", -1, "Fail below this precision (default: the corpus's min_precision)")
+	minRecall := flag.Float64("min-recall", -1, "Fail below this recall (default: the corpus's min_recall)")
+	flag.Parse()
+
+	corpus, err := scanner.LoadCorpus(*corpusFile)
+	if err != nil {
+		log.Fatalf("Failed to load corpus: %v", err)
+	}
+	if *minPrecision >= 0 {
+		corpus.MinPrecision = *minPrecision
+	}
+	if *minRecall >= 0 {
+		corpus.MinRecall = *minRecall
+	}
+
+	patterns, err := scanner.LoadPatterns(*patternsFile)
+	if err != nil {
+		log.Fatalf("Failed to load patterns: %v", err)
+	}
+
+	report := scanner.NewScannerWithPatterns(patterns).EvaluateCorpus(corpus.Cases)
+
+	fmt.Printf("%-32s %5s %5s %5s\n", "PATTERN", "TP", "FP", "FN")
+	for _, score := range report.Patterns {
+		fmt.Printf("%-32s %5d %5d %5d\n", score.Pattern, score.TruePositives, score.FalsePositives, score.FalseNegatives)
+	}
+	fmt.Println()
+
+	for _, c := range report.Misses {
+		if c.Pattern != "" {
+			fmt.Printf("missed (%s): %s\n", c.Pattern, scanner.Redact(c.Line))
+		} else {
+			fmt.Printf("unexpected finding: %s\n", scanner.Redact(c.Line))
+		}
+	}
+	if len(report.Misses) > 0 {
+		fmt.Println()
+	}
+
+	precision, recall := report.Precision(), report.Recall()
+	fmt.Printf("%d case(s): precision %.3f (min %.3f), recall %.3f (min %.3f)\n",
+		len(corpus.Cases), precision, corpus.MinPrecision, recall, corpus.MinRecall)
+
+	if precision < corpus.MinPrecision || recall < corpus.MinRecall {
+		fmt.Println("FAIL: detection quality is below the corpus thresholds")
+		os.Exit(1)
+	}
+}
@@ -1,16 +1,25 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"log"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
-	//"os"
-
+	"github.com/Rishav176/GitReviewed/internal/apierr"
 	"github.com/Rishav176/GitReviewed/internal/config"
 	"github.com/Rishav176/GitReviewed/internal/handlers"
 	"github.com/joho/godotenv"
 )
 
+// shutdownTimeout bounds how long shutdown waits for requests and pending
+// PR scans to finish
+const shutdownTimeout = 60 * time.Second
+
 func main() {
 	// Load .env file in development
 	if err := godotenv.Load(); err != nil {
@@ -26,23 +35,77 @@ func main() {
 	log.Printf("Starting GitReviewed in %s mode", cfg.Environment)
 
 	// Create webhook handler
-	handler := handlers.NewWebhookHandler(cfg)
+	handler, err := handlers.NewWebhookHandler(cfg)
+	if err != nil {
+		log.Fatalf("Failed to create webhook handler: %v", err)
+	}
+
+	// Fail fast on a token that can't do its job rather than with a 403
+	// on the first commit status. Other failures, like GitHub being
+	// unreachable, say nothing about the token and don't stop startup.
+	if cfg.GitHubAccessCheck {
+		err := handler.CheckGitHubAccess(context.Background())
+		switch {
+		case errors.Is(err, apierr.ErrAuth):
+			log.Fatalf("GitHub token check failed: %v (set GITHUB_ACCESS_CHECK=false to skip)", err)
+		case err != nil:
+			log.Printf("⚠️  GitHub token check could not complete, starting anyway: %v", err)
+		default:
+			log.Printf("GitHub token check passed for %d repo(s)", len(cfg.GitHubAccessCheckRepos))
+		}
+	}
+
+	// Backfill statuses for PRs opened before the tool was deployed
+	if cfg.StartupScan {
+		go handler.ScanOpenPullRequests(context.Background())
+	}
+
+	// Post the findings digest on schedule
+	if cfg.Digest {
+		go handler.RunDigest(context.Background())
+	}
 
 	// Register routes
 	// Register routes
 	http.HandleFunc("/webhook", handler.HandleWebhook)
 	http.HandleFunc("/health", handler.HealthCheck)
+	http.HandleFunc("/ready", handler.Ready)
 	http.HandleFunc("/test-slack", handler.TestSlack)
 	http.HandleFunc("/test-gemini", handler.TestGemini)
+	http.HandleFunc("/reload", handler.Reload)
+	http.HandleFunc("/test-scan", handler.TestScan)
+	http.HandleFunc("/history", handler.History)
+	http.HandleFunc("/approve", handler.Approve)
+	http.HandleFunc("/patterns", handler.Patterns)
 
 	// Start server
 	addr := ":" + cfg.Port
 	log.Printf("Server listening on %s", addr)
 	log.Printf("Webhook endpoint: http://localhost%s/webhook", addr)
 	log.Printf("Health check: http://localhost%s/health", addr)
+	log.Printf("Readiness check: http://localhost%s/ready", addr)
 	log.Printf("Test Slack: http://localhost%s/test-slack", addr)
 
-	if err := http.ListenAndServe(addr, nil); err != nil {
-		log.Fatalf("Server failed to start: %v", err)
+	server := &http.Server{Addr: addr}
+	go func() {
+		if err := server.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("Server failed to start: %v", err)
+		}
+	}()
+
+	// Stop taking webhooks on SIGINT or SIGTERM, then finish the PR scans
+	// still waiting out their debounce window
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	<-ctx.Done()
+	log.Printf("Shutting down")
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil {
+		log.Printf("Error shutting down the server: %v", err)
+	}
+	if err := handler.FlushPending(shutdownCtx); err != nil {
+		log.Printf("Error finishing pending PR scans: %v", err)
 	}
-}
\ No newline at end of file
+}
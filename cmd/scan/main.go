@@ -0,0 +1,158 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/scanner"
+)
+
+// maxFileSize skips files too large to be source code
+const maxFileSize = 1 << 20
+
+func main() {
+	dir := flag.String("dir", ".", "Directory to scan")
+	patternsFile := flag.String("patterns", "", "YAML file with additional secret patterns")
+	baselineFile := flag.String("baseline", "", "Baseline of known findings to ignore")
+	writeBaseline := flag.String("write-baseline", "", "Write all findings to this baseline file")
+	concurrency := flag.Int("concurrency", runtime.NumCPU(), "Number of files to scan in parallel")
+	decodeBase64 := flag.Bool("base64", false, "Also scan the decoded content of base64 runs")
+	format := flag.String("format", "text", "Output format: text, json, or github for GitHub Actions annotations")
+	stream := flag.Bool("stream", false, "Print text findings as each file is scanned, in no particular file order")
+	flag.Parse()
+
+	if *format != "text" && *format != "json" && *format != "github" {
+		log.Fatalf("Invalid -format %q (expected text, json or github)", *format)
+	}
+	if *stream && (*format != "text" || *writeBaseline != "") {
+		log.Fatalf("-stream only works with -format text and without -write-baseline")
+	}
+
+	patterns, err := scanner.LoadPatterns(*patternsFile)
+	if err != nil {
+		log.Fatalf("Failed to load patterns: %v", err)
+	}
+
+	baseline, err := scanner.LoadBaseline(*baselineFile)
+	if err != nil {
+		log.Fatalf("Failed to load baseline: %v", err)
+	}
+
+	s := scanner.NewScannerWithPatterns(patterns)
+	s.SetBaseline(baseline)
+	s.SetConcurrency(*concurrency)
+	s.SetDecodeBase64(*decodeBase64)
+
+	files, err := collectFiles(*dir)
+	if err != nil {
+		log.Fatalf("Failed to read %s: %v", *dir, err)
+	}
+
+	if *stream {
+		found := 0
+		s.ScanFilesStream(files, func(issue models.SecurityIssue) {
+			printIssue(issue)
+			found++
+		})
+		log.Printf("Scanned %d file(s), found %d issue(s)", len(files), found)
+		if found > 0 {
+			os.Exit(1)
+		}
+		return
+	}
+
+	result := s.ScanFiles(files)
+
+	if *writeBaseline != "" {
+		f, err := os.Create(*writeBaseline)
+		if err != nil {
+			log.Fatalf("Failed to create baseline: %v", err)
+		}
+		defer f.Close()
+
+		if err := scanner.NewBaseline(result.Issues).Write(f); err != nil {
+			log.Fatalf("Failed to write baseline: %v", err)
+		}
+		log.Printf("Wrote %d finding(s) to %s", len(result.Issues), *writeBaseline)
+		return
+	}
+
+	switch *format {
+	case "github":
+		if err := scanner.WriteAnnotations(os.Stdout, result.Issues); err != nil {
+			log.Fatalf("Failed to write annotations: %v", err)
+		}
+	case "json":
+		data, err := scanner.MarshalFindings(result)
+		if err != nil {
+			log.Fatalf("Failed to encode findings: %v", err)
+		}
+		fmt.Println(string(data))
+	default:
+		for _, issue := range result.Issues {
+			printIssue(issue)
+		}
+	}
+	log.Printf("Scanned %d file(s), found %d issue(s)", result.TotalFiles, len(result.Issues))
+
+	if result.Found {
+		os.Exit(1)
+	}
+}
+
+// printIssue prints a finding in the text format
+func printIssue(issue models.SecurityIssue) {
+	fmt.Printf("%s:%d: [%s] %s (%s)\n", issue.FilePath, issue.LineNumber, issue.Severity, issue.Description, issue.Fingerprint)
+}
+
+// collectFiles reads every text file under dir as an all-additions diff
+func collectFiles(dir string) ([]models.DiffFile, error) {
+	var files []models.DiffFile
+
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil || info.Size() > maxFileSize {
+			return nil
+		}
+
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		// Skip binary files
+		if bytes.IndexByte(content, 0) >= 0 {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, path)
+		if err != nil {
+			rel = path
+		}
+
+		files = append(files, models.DiffFile{
+			Filename: filepath.ToSlash(rel),
+			Status:   "added",
+			Patch:    scanner.ContentAsDiff(string(content)),
+		})
+		return nil
+	})
+
+	return files, err
+}
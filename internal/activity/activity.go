@@ -0,0 +1,44 @@
+// Package activity keeps a short record of recently processed webhook
+// events, so operators can check whether an event arrived and what came
+// of it without searching the logs.
+package activity
+
+import (
+	"context"
+	"time"
+)
+
+// Outcomes of a processed event
+const (
+	OutcomeBlocked  = "blocked"
+	OutcomeFindings = "findings"
+	OutcomeClean    = "clean"
+	OutcomeIgnored  = "ignored"
+	OutcomeError    = "error"
+)
+
+// Event describes one processed webhook event
+type Event struct {
+	Time       time.Time `json:"time"`
+	DeliveryID string    `json:"delivery_id,omitempty"`
+	Event      string    `json:"event"`
+	Action     string    `json:"action,omitempty"`
+	Repository string    `json:"repository,omitempty"`
+	PRNumber   int       `json:"pr_number,omitempty"`
+	Ref        string    `json:"ref,omitempty"`
+	Findings   int       `json:"findings"`
+	Outcome    string    `json:"outcome"`
+	Error      string    `json:"error,omitempty"`
+	DurationMS int64     `json:"duration_ms"`
+}
+
+// Store records processed events. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Record adds an event
+	Record(ctx context.Context, event Event) error
+
+	// Recent returns up to limit events, newest first; limit <= 0
+	// returns all that are kept
+	Recent(ctx context.Context, limit int) ([]Event, error)
+}
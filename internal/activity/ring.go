@@ -0,0 +1,53 @@
+package activity
+
+import (
+	"context"
+	"sync"
+)
+
+// Ring keeps the most recent events in memory, dropping the oldest once
+// it is full. Events are lost on restart.
+type Ring struct {
+	mu     sync.Mutex
+	events []Event
+	next   int
+	full   bool
+}
+
+// NewRing creates a ring holding up to size events; size must be positive
+func NewRing(size int) *Ring {
+	return &Ring{events: make([]Event, size)}
+}
+
+// Record adds an event, replacing the oldest when the ring is full
+func (r *Ring) Record(ctx context.Context, event Event) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events[r.next] = event
+	r.next = (r.next + 1) % len(r.events)
+	if r.next == 0 {
+		r.full = true
+	}
+	return nil
+}
+
+// Recent returns up to limit events, newest first
+func (r *Ring) Recent(ctx context.Context, limit int) ([]Event, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	count := r.next
+	if r.full {
+		count = len(r.events)
+	}
+	if limit > 0 && limit < count {
+		count = limit
+	}
+
+	recent := make([]Event, 0, count)
+	for i := 1; i <= count; i++ {
+		recent = append(recent, r.events[(r.next-i+len(r.events))%len(r.events)])
+	}
+	return recent, nil
+}
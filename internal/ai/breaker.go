@@ -0,0 +1,119 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// ErrCircuitOpen is returned while the circuit breaker is short-circuiting
+// reviews after repeated failures
+var ErrCircuitOpen = errors.New("AI review temporarily unavailable")
+
+// breakerState is the state of a Breaker
+type breakerState int
+
+const (
+	// breakerClosed passes reviews through
+	breakerClosed breakerState = iota
+	// breakerOpen fails reviews without calling the backend
+	breakerOpen
+	// breakerHalfOpen lets a single trial review through to test recovery
+	breakerHalfOpen
+)
+
+// Breaker is a Reviewer that stops calling the backend after a number of
+// consecutive failed reviews. While open, reviews fail with ErrCircuitOpen
+// until the cooldown passes; the next review is then a trial that closes
+// the breaker if it succeeds and reopens it if it fails. Quota errors
+// don't count as failures, since the quota cooldown already handles them,
+// and neither does a PR with nothing to review.
+type Breaker struct {
+	next      Reviewer
+	threshold int
+	cooldown  time.Duration
+
+	mu       sync.Mutex
+	state    breakerState
+	failures int
+	until    time.Time
+}
+
+// NewBreaker wraps next with a circuit breaker that opens after threshold
+// consecutive failures for cooldown. A threshold of zero or less returns
+// next unchanged.
+func NewBreaker(next Reviewer, threshold int, cooldown time.Duration) Reviewer {
+	if threshold <= 0 {
+		return next
+	}
+	return &Breaker{next: next, threshold: threshold, cooldown: cooldown}
+}
+
+// ReviewCodeByFile reviews the PR unless the breaker is open
+func (b *Breaker) ReviewCodeByFile(ctx context.Context, rc models.ReviewContext) (models.ReviewResult, error) {
+	if err := b.allow(); err != nil {
+		return models.ReviewResult{}, err
+	}
+
+	result, err := b.next.ReviewCodeByFile(ctx, rc)
+	b.record(err)
+	return result, err
+}
+
+// TestConnection checks the backend directly, even while the breaker is
+// open
+func (b *Breaker) TestConnection() error {
+	return b.next.TestConnection()
+}
+
+// Model returns the wrapped reviewer's model
+func (b *Breaker) Model() string {
+	return b.next.Model()
+}
+
+// allow reports whether a review may call the backend, moving an open
+// breaker whose cooldown has passed to half-open
+func (b *Breaker) allow() error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case breakerOpen:
+		if time.Now().Before(b.until) {
+			return fmt.Errorf("%w until %s", ErrCircuitOpen, b.until.Format(time.Kitchen))
+		}
+		log.Printf("AI circuit breaker half-open, trying a review")
+		b.state = breakerHalfOpen
+	case breakerHalfOpen:
+		// Only the trial review goes through
+		return fmt.Errorf("%w while a trial review runs", ErrCircuitOpen)
+	}
+	return nil
+}
+
+// record updates the breaker with the outcome of a review
+func (b *Breaker) record(err error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if err == nil || errors.Is(err, ErrQuotaExceeded) || errors.Is(err, ErrNothingToReview) {
+		if b.state == breakerHalfOpen {
+			log.Printf("AI circuit breaker closed, reviews resumed")
+		}
+		b.state = breakerClosed
+		b.failures = 0
+		return
+	}
+
+	b.failures++
+	if b.state == breakerHalfOpen || b.failures >= b.threshold {
+		log.Printf("🚨 AI circuit breaker open after %d consecutive failure(s), pausing AI review for %s", b.failures, b.cooldown)
+		b.state = breakerOpen
+		b.until = time.Now().Add(b.cooldown)
+	}
+}
@@ -0,0 +1,82 @@
+package ai
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// ReviewCache stores per-file reviews so unchanged files are not
+// re-reviewed on every push
+type ReviewCache interface {
+	// Get returns the cached review for key, if present
+	Get(key string) (string, bool)
+
+	// Set stores a review under key
+	Set(key, review string)
+}
+
+// ReviewCacheKey derives a cache key from a file's path and the patch
+// sent for review
+func ReviewCacheKey(filename, patch string) string {
+	sum := sha256.Sum256([]byte(filename + "\x00" + patch))
+	return hex.EncodeToString(sum[:])
+}
+
+// maxCacheEntries triggers a sweep of expired entries when exceeded
+const maxCacheEntries = 10000
+
+// MemoryCache is an in-memory ReviewCache whose entries expire after a TTL
+type MemoryCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+// cacheEntry is a cached review and its expiry time
+type cacheEntry struct {
+	review  string
+	expires time.Time
+}
+
+// NewMemoryCache creates an in-memory cache with the given TTL
+func NewMemoryCache(ttl time.Duration) *MemoryCache {
+	return &MemoryCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// Get returns the cached review for key if it has not expired
+func (c *MemoryCache) Get(key string) (string, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return "", false
+	}
+	if time.Now().After(entry.expires) {
+		delete(c.entries, key)
+		return "", false
+	}
+	return entry.review, true
+}
+
+// Set stores a review under key
+func (c *MemoryCache) Set(key, review string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	if len(c.entries) >= maxCacheEntries {
+		for k, entry := range c.entries {
+			if now.After(entry.expires) {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	c.entries[key] = cacheEntry{review: review, expires: now.Add(c.ttl)}
+}
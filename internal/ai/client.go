@@ -2,154 +2,93 @@ package ai
 
 import (
 	"context"
-	"fmt"
-	"log"
-	"os"
-	"strings"
+	"net/http"
 	"time"
 
 	"github.com/Rishav176/GitReviewed/internal/models"
-	"google.golang.org/genai"
+	"golang.org/x/time/rate"
 )
 
-// Client handles AI API interactions using Google's official SDK
-type Client struct {
-	client *genai.Client
-}
+// Reviewer defines the interface for AI code review backends
+type Reviewer interface {
+	// ReviewCodeByFile reviews each changed file and combines the results.
+	// It fails only when no file could be reviewed; files that failed are
+	// listed in the result.
+	ReviewCodeByFile(ctx context.Context, rc models.ReviewContext) (models.ReviewResult, error)
 
-// NewClient creates a new AI client using the official Google SDK
-func NewClient(apiKey string) *Client {
-	ctx := context.Background()
-	
-	// Set API key as environment variable (SDK reads from GEMINI_API_KEY)
-	os.Setenv("GEMINI_API_KEY", apiKey)
-	
-	// Create client (nil means it will use GEMINI_API_KEY from environment)
-	client, err := genai.NewClient(ctx, nil)
-	if err != nil {
-		log.Printf("Failed to create Gemini client: %v", err)
-		return nil
-	}
+	// TestConnection verifies the backend is reachable
+	TestConnection() error
 
-	return &Client{
-		client: client,
-	}
+	// Model returns the name of the model used for reviews
+	Model() string
 }
 
-// TestConnection tests the Gemini API with a simple request
-func (c *Client) TestConnection() error {
-	ctx := context.Background()
-
-	result, err := c.client.Models.GenerateContent(
-		ctx,
-		"gemini-2.5-flash",
-		genai.Text("Say hello in one word"),
-		nil,
-	)
-	if err != nil {
-		return fmt.Errorf("API test failed: %w", err)
-	}
+// Options holds optional settings for AI reviewers
+type Options struct {
+	// PromptTemplate overrides the built-in per-file review prompt
+	PromptTemplate string
 
-	if result.Text() == "" {
-		return fmt.Errorf("empty response from API")
-	}
+	// PRPromptTemplate overrides the built-in prompt of
+	// BuildReviewPrompt
+	PRPromptTemplate string
 
-	return nil
-}
+	// Cache stores per-file reviews; nil disables caching
+	Cache ReviewCache
 
-// ReviewSingleFile reviews a single file
-func (c *Client) ReviewSingleFile(filename string, patch string, additions, deletions int) (string, error) {
-	ctx := context.Background()
+	// Depth selects the review instructions and patch budget
+	Depth Depth
 
-	prompt := fmt.Sprintf(`You are an experienced code reviewer. Review this single file change.
+	// MaxFilePatchChars and MaxFilePatchLines cap the patch sent for each
+	// file, overriding the depth's budget when positive
+	MaxFilePatchChars int
+	MaxFilePatchLines int
 
-**File:** %s
-**Changes:** +%d additions, -%d deletions
+	// Priority orders files before review; PriorityPaths are the globs
+	// used by PriorityPath, most important first
+	Priority      Priority
+	PriorityPaths []string
 
-**Diff:**
-`+"```diff\n%s\n```"+`
+	// MaxFiles caps how many files are reviewed per PR; 0 means no limit.
+	// The lowest-priority files are skipped first.
+	MaxFiles int
 
-**Instructions:**
-1. Review for bugs, performance issues, and best practices
-2. Suggest specific improvements with line references if possible
-3. Point out security issues
-4. If the code looks good, briefly say so
-5. Be concise - max 3-4 sentences per issue
+	// HTTPClient is used for API calls; nil uses the backend's default
+	HTTPClient *http.Client
 
-**Your review:**`, filename, additions, deletions, patch)
+	// QuotaCooldown pauses AI review after a quota error; reviews during
+	// the cooldown fail with ErrQuotaExceeded without calling the API
+	QuotaCooldown time.Duration
 
-	log.Printf("📊 Prompt size: %d characters", len(prompt))
+	// Verdict asks the model to start each review with a verdict line;
+	// the PR's overall verdict is returned in ReviewResult.Verdict
+	Verdict bool
 
-	result, err := c.client.Models.GenerateContent(
-		ctx,
-		"gemini-2.5-flash",
-		genai.Text(prompt),
-		nil,
-	)
-	if err != nil {
-		return "", fmt.Errorf("API request failed: %w", err)
-	}
+	// InlineComments asks the model to reference specific lines in a
+	// "file:line: comment" format, returned in ReviewResult.Comments
+	InlineComments bool
 
-	return result.Text(), nil
-}
+	// Languages limits the review to files in these languages, as named
+	// in review prompts; empty reviews every language
+	Languages []string
 
-// ReviewCodeByFile reviews each file individually and combines results
-func (c *Client) ReviewCodeByFile(ctx models.ReviewContext) (string, error) {
-	var allReviews strings.Builder
-
-	allReviews.WriteString(fmt.Sprintf("**PR Review for #%d: %s**\n\n", ctx.PullRequest.Number, ctx.PullRequest.Title))
-
-	filesReviewed := 0
-	filesFailed := 0
-
-	// Review each file individually
-	for i, file := range ctx.DiffFiles {
-		// Skip binary files or files without patches
-		if file.Patch == "" {
-			continue
-		}
-
-		// Truncate very large diffs
-		patch := file.Patch
-		if len(patch) > 5000 { // ~100-150 lines of diff
-			lines := strings.Split(patch, "\n")
-			if len(lines) > 100 {
-				patch = strings.Join(lines[:100], "\n")
-				patch += fmt.Sprintf("\n... (truncated %d lines)", len(lines)-100)
-			}
-		}
-
-		log.Printf("Reviewing file %d/%d: %s", i+1, len(ctx.DiffFiles), file.Filename)
-
-		review, err := c.ReviewSingleFile(file.Filename, patch, file.Additions, file.Deletions)
-		if err != nil {
-			log.Printf("Failed to review %s: %v", file.Filename, err)
-			allReviews.WriteString(fmt.Sprintf("\n### %s\n", file.Filename))
-			allReviews.WriteString("_Could not review this file due to API error_\n\n")
-			filesFailed++
-			continue
-		}
-
-		allReviews.WriteString(fmt.Sprintf("\n### %s\n", file.Filename))
-		allReviews.WriteString(review)
-		allReviews.WriteString("\n\n")
-		filesReviewed++
-
-		// Rate limiting: wait 2 seconds between requests
-		if i < len(ctx.DiffFiles)-1 {
-			time.Sleep(2 * time.Second)
-		}
-	}
+	// SkipTrivial leaves out files whose changes are only whitespace or
+	// comments
+	SkipTrivial bool
 
-	if filesReviewed == 0 {
-		return "", fmt.Errorf("failed to review any files (%d failed)", filesFailed)
-	}
+	// AddedLinesOnly sends only the added lines of each patch, under
+	// their hunk headers, instead of the whole patch
+	AddedLinesOnly bool
 
-	// Add overall summary
-	allReviews.WriteString("\n---\n")
-	allReviews.WriteString(fmt.Sprintf("**Summary:** Reviewed %d/%d file(s) successfully\n",
-		filesReviewed,
-		len(ctx.DiffFiles)))
+	// Limiter paces API requests and may be shared between reviewers;
+	// nil means requests are not rate limited
+	Limiter *rate.Limiter
+}
 
-	return allReviews.String(), nil
-}
\ No newline at end of file
+// NewLimiter creates a limiter allowing requestsPerMinute API requests.
+// Zero or less disables rate limiting.
+func NewLimiter(requestsPerMinute int) *rate.Limiter {
+	if requestsPerMinute <= 0 {
+		return rate.NewLimiter(rate.Inf, 1)
+	}
+	return rate.NewLimiter(rate.Limit(float64(requestsPerMinute)/60), 1)
+}
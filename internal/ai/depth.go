@@ -0,0 +1,75 @@
+package ai
+
+// Depth controls how detailed AI reviews are
+type Depth string
+
+const (
+	// DepthBrief only reports blocking problems
+	DepthBrief Depth = "brief"
+	// DepthStandard gives a concise general review
+	DepthStandard Depth = "standard"
+	// DepthThorough reviews line by line with a larger patch budget
+	DepthThorough Depth = "thorough"
+)
+
+// depthProfile holds the prompt wording and patch budget for a depth
+type depthProfile struct {
+	instructions  string
+	closing       string
+	maxPatchChars int
+	maxPatchLines int
+}
+
+// depthProfiles maps each depth to its profile
+var depthProfiles = map[Depth]depthProfile{
+	DepthBrief: {
+		instructions: `1. Only report blocking problems: bugs, security issues, data loss or crashes
+2. Ignore style, naming and minor improvements
+3. Reference the affected lines
+4. If there is nothing blocking, reply "No blocking issues"
+5. At most 3 bullet points`,
+		closing:       "**List only blocking issues:**",
+		maxPatchChars: 3000,
+		maxPatchLines: 60,
+	},
+	DepthStandard: {
+		instructions: `1. Review for bugs, performance issues, and best practices
+2. Suggest specific improvements with line references if possible
+3. Point out security issues
+4. If the code looks good, briefly say so
+5. Be concise - max 3-4 sentences per issue`,
+		closing:       "**Please provide your code review in 2-3 paragraphs:**",
+		maxPatchChars: 5000, // ~100-150 lines of diff
+		maxPatchLines: 100,
+	},
+	DepthThorough: {
+		instructions: `1. Go through the change line by line, referencing line numbers
+2. Look for bugs, edge cases, error handling gaps, concurrency and performance issues
+3. Point out security issues and unsafe input handling
+4. Comment on readability, naming, tests and documentation
+5. Suggest concrete code for each improvement`,
+		closing:       "**Please provide a detailed, line-by-line review:**",
+		maxPatchChars: 20000,
+		maxPatchLines: 400,
+	},
+}
+
+// profileFor returns the profile for depth, defaulting to standard
+func profileFor(depth Depth) depthProfile {
+	if profile, ok := depthProfiles[depth]; ok {
+		return profile
+	}
+	return depthProfiles[DepthStandard]
+}
+
+// withLimits overrides the profile's patch budget with chars and lines
+// where they are positive
+func (p depthProfile) withLimits(chars, lines int) depthProfile {
+	if chars > 0 {
+		p.maxPatchChars = chars
+	}
+	if lines > 0 {
+		p.maxPatchLines = lines
+	}
+	return p
+}
@@ -0,0 +1,103 @@
+package ai
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"google.golang.org/genai"
+)
+
+// GeminiModel is the Gemini model used for reviews
+const GeminiModel = "gemini-2.5-flash"
+
+// GeminiClient implements the Reviewer interface using Google's official SDK
+type GeminiClient struct {
+	client *genai.Client
+	fileReviewer
+}
+
+// NewGeminiClient creates a new Gemini reviewer using the official Google SDK
+func NewGeminiClient(apiKey string, opts Options) *GeminiClient {
+	ctx := context.Background()
+
+	// Set API key as environment variable (SDK reads from GEMINI_API_KEY)
+	os.Setenv("GEMINI_API_KEY", apiKey)
+
+	// Create client (an empty APIKey means it will use GEMINI_API_KEY from environment)
+	client, err := genai.NewClient(ctx, &genai.ClientConfig{HTTPClient: opts.HTTPClient})
+	if err != nil {
+		log.Printf("Failed to create Gemini client: %v", err)
+		return nil
+	}
+
+	return &GeminiClient{
+		client:       client,
+		fileReviewer: newFileReviewer(opts),
+	}
+}
+
+// Model returns the name of the model used for reviews
+func (c *GeminiClient) Model() string {
+	return GeminiModel
+}
+
+// TestConnection tests the Gemini API with a simple request
+func (c *GeminiClient) TestConnection() error {
+	ctx := context.Background()
+
+	result, err := c.client.Models.GenerateContent(
+		ctx,
+		GeminiModel,
+		genai.Text("Say hello in one word"),
+		nil,
+	)
+	if err != nil {
+		return fmt.Errorf("API test failed: %w", err)
+	}
+
+	if result.Text() == "" {
+		return fmt.Errorf("empty response from API")
+	}
+
+	return nil
+}
+
+// ReviewSingleFile reviews a single file
+func (c *GeminiClient) ReviewSingleFile(filename string, patch string, additions, deletions int) (string, error) {
+	ctx := context.Background()
+
+	prompt, err := c.renderPrompt(FilePromptData{
+		Filename:  filename,
+		Patch:     patch,
+		Additions: additions,
+		Deletions: deletions,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("📊 Prompt size: %d characters", len(prompt))
+
+	result, err := c.client.Models.GenerateContent(
+		ctx,
+		GeminiModel,
+		genai.Text(prompt),
+		nil,
+	)
+	if err != nil {
+		if isGeminiQuotaError(err) {
+			return "", fmt.Errorf("%w: %v", ErrQuotaExceeded, err)
+		}
+		return "", fmt.Errorf("API request failed: %w", classifyGemini(err))
+	}
+
+	return result.Text(), nil
+}
+
+// ReviewCodeByFile reviews each file individually and combines results
+func (c *GeminiClient) ReviewCodeByFile(ctx context.Context, rc models.ReviewContext) (models.ReviewResult, error) {
+	return c.reviewByFile(ctx, rc, c.ReviewSingleFile)
+}
@@ -0,0 +1,58 @@
+package ai
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/diff"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// inlineInstruction is appended to the review instructions when inline
+// comments are requested
+const inlineInstruction = `
+Also put each finding about a specific line on its own line as "<file>:<line>: <comment>", using the new-file line numbers shown before each diff line`
+
+// inlineComment matches a "file:line: comment" finding, optionally in a
+// list item and with the reference in backticks or bold
+var inlineComment = regexp.MustCompile("^\\s*(?:[-*]\\s+)?[`*]*([^\\s:`*]+)[`*]*:(\\d+)[`*]*:\\s*(.+)$")
+
+// numberPatch prefixes each added or unchanged line of patch with its line
+// in the new file, so the model can reference lines reliably
+func numberPatch(patch string) string {
+	lines := strings.Split(patch, "\n")
+	for _, line := range diff.ParseLines(lines).Lines() {
+		i := line.Index - 1
+		if line.NewLine > 0 {
+			lines[i] = fmt.Sprintf("%5d %s", line.NewLine, lines[i])
+		} else {
+			lines[i] = "      " + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// parseInlineComments extracts the line-specific findings about filename
+// from a review. References to other files are ignored; a bare base name
+// is taken to mean filename.
+func parseInlineComments(filename, review string) []models.ReviewComment {
+	var comments []models.ReviewComment
+	for _, line := range strings.Split(review, "\n") {
+		m := inlineComment.FindStringSubmatch(line)
+		if m == nil {
+			continue
+		}
+		if m[1] != filename && m[1] != path.Base(filename) {
+			continue
+		}
+		n, err := strconv.Atoi(m[2])
+		if err != nil || n < 1 {
+			continue
+		}
+		comments = append(comments, models.ReviewComment{Path: filename, Line: n, Body: strings.TrimSpace(m[3])})
+	}
+	return comments
+}
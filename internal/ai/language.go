@@ -0,0 +1,132 @@
+package ai
+
+import (
+	"path"
+	"strings"
+)
+
+// languages maps file extensions to the language named in review prompts
+var languages = map[string]string{
+	".go":    "Go",
+	".py":    "Python",
+	".js":    "JavaScript",
+	".jsx":   "JavaScript (React)",
+	".mjs":   "JavaScript",
+	".ts":    "TypeScript",
+	".tsx":   "TypeScript (React)",
+	".java":  "Java",
+	".kt":    "Kotlin",
+	".scala": "Scala",
+	".rb":    "Ruby",
+	".php":   "PHP",
+	".cs":    "C#",
+	".c":     "C",
+	".h":     "C",
+	".cpp":   "C++",
+	".cc":    "C++",
+	".hpp":   "C++",
+	".rs":    "Rust",
+	".swift": "Swift",
+	".m":     "Objective-C",
+	".sh":    "Shell",
+	".bash":  "Shell",
+	".sql":   "SQL",
+	".tf":    "Terraform",
+	".yaml":  "YAML",
+	".yml":   "YAML",
+	".json":  "JSON",
+	".toml":  "TOML",
+	".html":  "HTML",
+	".css":   "CSS",
+	".scss":  "SCSS",
+	".vue":   "Vue",
+	".dart":  "Dart",
+	".lua":   "Lua",
+	".ex":    "Elixir",
+	".exs":   "Elixir",
+}
+
+// languageNames maps extensionless file names to their language
+var languageNames = map[string]string{
+	"Dockerfile": "Dockerfile",
+	"Makefile":   "Makefile",
+}
+
+// nonCodeExtensions lists extensions of files that aren't worth an AI
+// review: images, fonts, archives, documents and generated bundles
+var nonCodeExtensions = map[string]bool{
+	".png": true, ".jpg": true, ".jpeg": true, ".gif": true, ".bmp": true,
+	".ico": true, ".webp": true, ".svg": true,
+	".woff": true, ".woff2": true, ".ttf": true, ".otf": true, ".eot": true,
+	".zip": true, ".gz": true, ".tar": true, ".jar": true,
+	".pdf": true, ".map": true, ".snap": true,
+}
+
+// lockfiles lists dependency lockfiles, which are generated
+var lockfiles = map[string]bool{
+	"package-lock.json": true,
+	"yarn.lock":         true,
+	"pnpm-lock.yaml":    true,
+	"go.sum":            true,
+	"Cargo.lock":        true,
+	"Gemfile.lock":      true,
+	"composer.lock":     true,
+	"poetry.lock":       true,
+	"Pipfile.lock":      true,
+}
+
+// languageFor returns the language of filename, or "" when unknown
+func languageFor(filename string) string {
+	base := path.Base(filename)
+	if lang, ok := languageNames[base]; ok {
+		return lang
+	}
+	return languages[strings.ToLower(path.Ext(base))]
+}
+
+// InLanguages reports whether filename is written in one of languages,
+// compared case-insensitively; variants such as "JavaScript (React)" also
+// match their base language. An empty list allows every file.
+func InLanguages(filename string, languages []string) bool {
+	if len(languages) == 0 {
+		return true
+	}
+	lang := languageFor(filename)
+	if lang == "" {
+		return false
+	}
+	base, _, _ := strings.Cut(lang, " (")
+	for _, name := range languages {
+		if strings.EqualFold(name, lang) || strings.EqualFold(name, base) {
+			return true
+		}
+	}
+	return false
+}
+
+// KnownLanguage reports whether name is a language, or base language,
+// that files are detected as
+func KnownLanguage(name string) bool {
+	for _, names := range []map[string]string{languages, languageNames} {
+		for _, lang := range names {
+			base, _, _ := strings.Cut(lang, " (")
+			if strings.EqualFold(name, lang) || strings.EqualFold(name, base) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// SkipReview reports whether filename is a non-code file that shouldn't be
+// sent for AI review
+func SkipReview(filename string) bool {
+	base := path.Base(filename)
+	if lockfiles[base] {
+		return true
+	}
+	if strings.HasSuffix(base, ".min.js") || strings.HasSuffix(base, ".min.css") {
+		return true
+	}
+	return nonCodeExtensions[strings.ToLower(path.Ext(base))]
+}
@@ -0,0 +1,169 @@
+package ai
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/apierr"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// DefaultOpenAIModel is used when no OpenAI model is configured
+const DefaultOpenAIModel = "gpt-4o-mini"
+
+// openAIChatURL is the chat completions endpoint
+const openAIChatURL = "https://api.openai.com/v1/chat/completions"
+
+// OpenAIClient implements the Reviewer interface using OpenAI's chat
+// completions API
+type OpenAIClient struct {
+	apiKey     string
+	model      string
+	httpClient *http.Client
+	fileReviewer
+}
+
+// chatMessage is a single message in a chat completions request
+type chatMessage struct {
+	Role    string `json:"role"`
+	Content string `json:"content"`
+}
+
+// chatRequest is the chat completions request body
+type chatRequest struct {
+	Model     string        `json:"model"`
+	Messages  []chatMessage `json:"messages"`
+	MaxTokens int           `json:"max_tokens,omitempty"`
+}
+
+// chatResponse is the subset of the chat completions response we use
+type chatResponse struct {
+	Choices []struct {
+		Message chatMessage `json:"message"`
+	} `json:"choices"`
+	Error *struct {
+		Message string `json:"message"`
+		Type    string `json:"type"`
+	} `json:"error"`
+}
+
+// NewOpenAIClient creates a new OpenAI reviewer
+func NewOpenAIClient(apiKey, model string, opts Options) *OpenAIClient {
+	if model == "" {
+		model = DefaultOpenAIModel
+	}
+
+	httpClient := opts.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 2 * time.Minute}
+	}
+
+	return &OpenAIClient{
+		apiKey:       apiKey,
+		model:        model,
+		httpClient:   httpClient,
+		fileReviewer: newFileReviewer(opts),
+	}
+}
+
+// Model returns the name of the model used for reviews
+func (c *OpenAIClient) Model() string {
+	return c.model
+}
+
+// TestConnection tests the OpenAI API with a minimal completion
+func (c *OpenAIClient) TestConnection() error {
+	text, err := c.complete(context.Background(), "Say hello in one word", 5)
+	if err != nil {
+		return fmt.Errorf("API test failed: %w", err)
+	}
+
+	if text == "" {
+		return fmt.Errorf("empty response from API")
+	}
+
+	return nil
+}
+
+// ReviewSingleFile reviews a single file
+func (c *OpenAIClient) ReviewSingleFile(filename string, patch string, additions, deletions int) (string, error) {
+	prompt, err := c.renderPrompt(FilePromptData{
+		Filename:  filename,
+		Patch:     patch,
+		Additions: additions,
+		Deletions: deletions,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	log.Printf("📊 Prompt size: %d characters", len(prompt))
+
+	text, err := c.complete(context.Background(), prompt, 0)
+	if err != nil {
+		return "", fmt.Errorf("API request failed: %w", err)
+	}
+
+	return text, nil
+}
+
+// ReviewCodeByFile reviews each file individually and combines results
+func (c *OpenAIClient) ReviewCodeByFile(ctx context.Context, rc models.ReviewContext) (models.ReviewResult, error) {
+	return c.reviewByFile(ctx, rc, c.ReviewSingleFile)
+}
+
+// complete sends a single-message chat completion and returns the reply
+func (c *OpenAIClient) complete(ctx context.Context, prompt string, maxTokens int) (string, error) {
+	body, err := json.Marshal(chatRequest{
+		Model:     c.model,
+		Messages:  []chatMessage{{Role: "user", Content: prompt}},
+		MaxTokens: maxTokens,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, openAIChatURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return "", apierr.FromNetwork(err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", apierr.FromNetwork(fmt.Errorf("failed to read response: %w", err))
+	}
+
+	var result chatResponse
+	if err := json.Unmarshal(data, &result); err != nil {
+		return "", apierr.Wrap(apierr.FromStatus(resp.StatusCode), fmt.Errorf("failed to parse response (status %d): %w", resp.StatusCode, err))
+	}
+
+	if result.Error != nil {
+		if result.Error.Type == "insufficient_quota" {
+			return "", fmt.Errorf("%w: %s", ErrQuotaExceeded, result.Error.Message)
+		}
+		return "", apierr.Wrap(apierr.FromStatus(resp.StatusCode), fmt.Errorf("status %d: %s", resp.StatusCode, result.Error.Message))
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", apierr.Wrap(apierr.FromStatus(resp.StatusCode), fmt.Errorf("unexpected status %d", resp.StatusCode))
+	}
+	if len(result.Choices) == 0 {
+		return "", fmt.Errorf("no choices in response")
+	}
+
+	return result.Choices[0].Message.Content, nil
+}
@@ -0,0 +1,57 @@
+package ai
+
+import (
+	"sort"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/pathmatch"
+)
+
+// Priority controls the order in which files are reviewed
+type Priority string
+
+const (
+	// PriorityNone keeps the order returned by GitHub
+	PriorityNone Priority = ""
+	// PriorityAdditions reviews files with the most added lines first
+	PriorityAdditions Priority = "additions"
+	// PriorityPath reviews files matching the priority paths first
+	PriorityPath Priority = "path"
+	// PriorityAlpha reviews files in alphabetical order
+	PriorityAlpha Priority = "alpha"
+)
+
+// SortFiles returns a copy of files ordered by priority. Sorting is stable,
+// so ties keep the order returned by GitHub.
+func SortFiles(files []models.DiffFile, priority Priority, priorityPaths []string) []models.DiffFile {
+	sorted := make([]models.DiffFile, len(files))
+	copy(sorted, files)
+
+	switch priority {
+	case PriorityAdditions:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Additions > sorted[j].Additions
+		})
+	case PriorityPath:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return pathRank(sorted[i].Filename, priorityPaths) < pathRank(sorted[j].Filename, priorityPaths)
+		})
+	case PriorityAlpha:
+		sort.SliceStable(sorted, func(i, j int) bool {
+			return sorted[i].Filename < sorted[j].Filename
+		})
+	}
+
+	return sorted
+}
+
+// pathRank returns the index of the first pattern matching filename, or
+// len(patterns) when none match. A pattern ending in "/" matches everything
+// under that directory; other patterns are globs matched against the full
+// path and the base name.
+func pathRank(filename string, patterns []string) int {
+	if i := pathmatch.Index(patterns, filename); i >= 0 {
+		return i
+	}
+	return len(patterns)
+}
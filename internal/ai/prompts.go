@@ -2,80 +2,304 @@ package ai
 
 import (
 	"fmt"
+	"io"
 	"strings"
+	"text/template"
+	"unicode/utf8"
 
+	"github.com/Rishav176/GitReviewed/internal/diff"
 	"github.com/Rishav176/GitReviewed/internal/models"
 )
 
 const (
-	MaxDiffLines     = 30   // REDUCED: Max lines per file
-	MaxTotalFiles    = 3    // REDUCED: Max files to review
-	MaxPromptLength  = 10000 // REDUCED: Max characters in prompt
+	MaxTotalFiles   = 3     // REDUCED: Max files to review
+	MaxPromptLength = 10000 // REDUCED: Max characters in prompt
 )
 
-// BuildReviewPrompt creates a prompt for code review with size limits
-func BuildReviewPrompt(ctx models.ReviewContext) string {
+// DefaultFilePromptTemplate is the built-in per-file review prompt. Custom
+// templates receive the same FilePromptData fields.
+const DefaultFilePromptTemplate = `You are an experienced code reviewer. Review this single file change.
+
+**File:** {{.Filename}}{{if .Language}}
+**Language:** {{.Language}}. Follow {{.Language}} idioms and conventions.{{end}}
+**Changes:** +{{.Additions}} additions, -{{.Deletions}} deletions
+
+**Diff:**
+` + "```diff\n{{.Patch}}\n```" + `
+
+**Instructions:**
+{{.Instructions}}
+
+**Your review:**`
+
+// FilePromptData is the data available to per-file prompt templates
+type FilePromptData struct {
+	Filename  string
+	Patch     string
+	Additions int
+	Deletions int
+	// Language is detected from the file extension, or empty if unknown
+	Language string
+	// Instructions are the review instructions for the configured depth
+	Instructions string
+}
+
+// DefaultPRPromptTemplate is the built-in prompt of BuildReviewPrompt,
+// reviewing a whole PR at once. Custom templates receive the same
+// PRPromptData fields.
+const DefaultPRPromptTemplate = `You are an experienced code reviewer. Please review the following pull request changes.
+
+**Repository:** {{.Repository}}
+**PR Title:** {{.Title}}
+**Author:** {{.Author}}
+**Branch:** {{.HeadRef}} → {{.BaseRef}}
+**Head Commit:** {{.HeadSHA}}
+
+**Instructions:**
+{{.Instructions}}
+
+{{if .SizeLimited}}**Note:** Only reviewing first {{len .Files}} of {{.TotalFiles}} files due to size limits.
+
+{{end}}**Changed Files:** ({{len .Files}} files)
+
+{{range .Files}}### File: ` + "`{{.Filename}}`" + ` ({{.Status}})
+**Changes:** +{{.Additions}} additions, -{{.Deletions}} deletions
+
+{{if .Patch}}` + "```diff\n{{.Patch}}\n```" + `
+
+{{end}}{{end}}{{if .LengthLimited}}
+**Note:** Stopped at {{len .Files}} files due to length limit.
+{{end}}
+{{.Closing}}`
+
+// PRPromptData is the data available to PR prompt templates
+type PRPromptData struct {
+	Repository string
+	Title      string
+	Author     string
+	HeadRef    string
+	BaseRef    string
+	HeadSHA    string
+	// Instructions and Closing are the review instructions and the
+	// closing request for the configured depth
+	Instructions string
+	Closing      string
+	// Files are the files included in the prompt, out of TotalFiles.
+	// SizeLimited is set when files were left out over MaxTotalFiles,
+	// LengthLimited when they were left out over MaxPromptLength.
+	Files         []PRPromptFile
+	TotalFiles    int
+	SizeLimited   bool
+	LengthLimited bool
+}
+
+// PRPromptFile is a file in PRPromptData. Patch is truncated like in
+// per-file reviews, and empty for binary files.
+type PRPromptFile struct {
+	Filename  string
+	Status    string
+	Additions int
+	Deletions int
+	Patch     string
+}
+
+// sampleFilePromptData and samplePRPromptData fill every field a prompt
+// template may use, so templates are rendered once when parsed and field
+// typos fail at startup
+var (
+	sampleFilePromptData = FilePromptData{
+		Filename:     "main.go",
+		Patch:        "@@ -1 +1 @@\n-old\n+new",
+		Additions:    1,
+		Deletions:    1,
+		Language:     "Go",
+		Instructions: "Review the change.",
+	}
+	samplePRPromptData = PRPromptData{
+		Repository:   "owner/repo",
+		Title:        "Sample change",
+		Author:       "octocat",
+		HeadRef:      "feature",
+		BaseRef:      "main",
+		HeadSHA:      "abc1234",
+		Instructions: "Review the change.",
+		Closing:      "Your review:",
+		Files: []PRPromptFile{
+			{Filename: "main.go", Status: "modified", Additions: 1, Deletions: 1, Patch: "@@ -1 +1 @@\n-old\n+new"},
+		},
+		TotalFiles: 1,
+	}
+)
+
+// ParsePromptTemplate parses a per-file prompt template, falling back to
+// the built-in template when text is empty. The template is rendered with
+// sample data, so references to unknown fields are reported here rather
+// than on the first review.
+func ParsePromptTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultFilePromptTemplate
+	}
+	return parsePromptTemplate("review", text, sampleFilePromptData)
+}
+
+// ParsePRPromptTemplate parses a PR prompt template for BuildReviewPrompt
+// like ParsePromptTemplate, falling back to DefaultPRPromptTemplate
+func ParsePRPromptTemplate(text string) (*template.Template, error) {
+	if text == "" {
+		text = DefaultPRPromptTemplate
+	}
+	return parsePromptTemplate("pr-review", text, samplePRPromptData)
+}
+
+// parsePromptTemplate parses text and checks that it renders with sample
+func parsePromptTemplate(name, text string, sample interface{}) (*template.Template, error) {
+	tmpl, err := template.New(name).Parse(text)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse prompt template: %w", err)
+	}
+	if err := tmpl.Execute(io.Discard, sample); err != nil {
+		return nil, fmt.Errorf("failed to render prompt template: %w", err)
+	}
+
+	return tmpl, nil
+}
+
+// renderFilePrompt executes a per-file prompt template
+func renderFilePrompt(tmpl *template.Template, data FilePromptData) (string, error) {
 	var prompt strings.Builder
+	if err := tmpl.Execute(&prompt, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt template: %w", err)
+	}
+	return prompt.String(), nil
+}
 
-	prompt.WriteString("You are an experienced code reviewer. Please review the following pull request changes.\n\n")
-	prompt.WriteString(fmt.Sprintf("**Repository:** %s\n", ctx.Repository.FullName))
-	prompt.WriteString(fmt.Sprintf("**PR Title:** %s\n", ctx.PullRequest.Title))
-	prompt.WriteString(fmt.Sprintf("**Author:** %s\n\n", ctx.PullRequest.User.Login))
-
-	prompt.WriteString("**Instructions:**\n")
-	prompt.WriteString("1. Review the code for bugs, performance issues, and best practices\n")
-	prompt.WriteString("2. Suggest improvements where applicable\n")
-	prompt.WriteString("3. Point out any potential security issues\n")
-	prompt.WriteString("4. If the code looks good, say so!\n")
-	prompt.WriteString("5. Be constructive and helpful\n")
-	prompt.WriteString("6. Keep your review concise\n\n")
-
-	// Limit number of files
-	filesToReview := ctx.DiffFiles
-	if len(filesToReview) > MaxTotalFiles {
-		prompt.WriteString(fmt.Sprintf("**Note:** Only reviewing first %d of %d files due to size limits.\n\n", MaxTotalFiles, len(filesToReview)))
-		filesToReview = filesToReview[:MaxTotalFiles]
-	}
-
-	prompt.WriteString(fmt.Sprintf("**Changed Files:** (%d files)\n\n", len(filesToReview)))
-
-	// Add each file's changes (with truncation)
-	filesAdded := 0
-	for _, file := range filesToReview {
-		// Check if adding this file would exceed limit
-		if prompt.Len() > MaxPromptLength {
-			prompt.WriteString(fmt.Sprintf("\n**Note:** Stopped at %d files due to length limit.\n", filesAdded))
+// BuildReviewPrompt creates a prompt reviewing the whole PR at once from
+// opts.PRPromptTemplate, or DefaultPRPromptTemplate when it is empty. At
+// most MaxTotalFiles files are included, and files stop being added once
+// the patches pass MaxPromptLength characters. Each patch is truncated
+// like in per-file reviews.
+func BuildReviewPrompt(ctx models.ReviewContext, opts Options) (string, error) {
+	tmpl, err := ParsePRPromptTemplate(opts.PRPromptTemplate)
+	if err != nil {
+		return "", err
+	}
+	profile := profileFor(opts.Depth).withLimits(opts.MaxFilePatchChars, opts.MaxFilePatchLines)
+
+	data := PRPromptData{
+		Repository:   ctx.Repository.FullName,
+		Title:        ctx.PullRequest.Title,
+		Author:       ctx.PullRequest.User.Login,
+		HeadRef:      ctx.PullRequest.Head.Ref,
+		BaseRef:      ctx.PullRequest.Base.Ref,
+		HeadSHA:      models.ShortSHA(ctx.PullRequest.Head.SHA),
+		Instructions: profile.instructions,
+		Closing:      profile.closing,
+		TotalFiles:   len(ctx.DiffFiles),
+	}
+
+	files := ctx.DiffFiles
+	if len(files) > MaxTotalFiles {
+		data.SizeLimited = true
+		files = files[:MaxTotalFiles]
+	}
+
+	length := 0
+	for _, file := range files {
+		if length > MaxPromptLength {
+			data.LengthLimited = true
 			break
 		}
 
-		prompt.WriteString(fmt.Sprintf("### File: `%s` (%s)\n", file.Filename, file.Status))
-		prompt.WriteString(fmt.Sprintf("**Changes:** +%d additions, -%d deletions\n\n", file.Additions, file.Deletions))
-
-		if file.Patch != "" {
-			truncatedPatch := truncateDiff(file.Patch, MaxDiffLines)
-			prompt.WriteString("```diff\n")
-			prompt.WriteString(truncatedPatch)
-			prompt.WriteString("\n```\n\n")
+		entry := PRPromptFile{
+			Filename:  file.Filename,
+			Status:    file.Status,
+			Additions: file.Additions,
+			Deletions: file.Deletions,
 		}
+		patch := file.Patch
+		if opts.AddedLinesOnly {
+			patch = addedOnlyPatch(patch)
+		}
+		if patch != "" {
+			entry.Patch = truncatePatch(patch, profile.maxPatchChars, profile.maxPatchLines)
+		}
+		length += len(entry.Patch)
+		data.Files = append(data.Files, entry)
+	}
 
-		filesAdded++
+	var prompt strings.Builder
+	if err := tmpl.Execute(&prompt, data); err != nil {
+		return "", fmt.Errorf("failed to render prompt template: %w", err)
 	}
+	return prompt.String(), nil
+}
 
-	prompt.WriteString("\n**Please provide your code review in 2-3 paragraphs:**")
+// addedOnlyPatch keeps only the added lines of patch. Each run of added
+// lines gets its own hunk header, so new-file line numbers still follow
+// from the patch. A patch without added lines becomes "".
+func addedOnlyPatch(patch string) string {
+	var out strings.Builder
+	for _, hunk := range diff.Parse(patch).Hunks {
+		oldLine := hunk.OldStart - 1
+		for i := 0; i < len(hunk.Lines); i++ {
+			line := hunk.Lines[i]
+			if line.Kind != diff.Added {
+				if line.OldLine > 0 {
+					oldLine = line.OldLine
+				}
+				continue
+			}
 
-	return prompt.String()
+			run := i
+			for i+1 < len(hunk.Lines) && hunk.Lines[i+1].Kind == diff.Added {
+				i++
+			}
+			fmt.Fprintf(&out, "@@ -%d,0 +%d,%d @@", oldLine, line.NewLine, i-run+1)
+			if hunk.Section != "" {
+				out.WriteString(" " + hunk.Section)
+			}
+			for _, added := range hunk.Lines[run : i+1] {
+				out.WriteString("\n+" + added.Text)
+			}
+			out.WriteString("\n")
+		}
+	}
+	return strings.TrimSuffix(out.String(), "\n")
 }
 
-// truncateDiff truncates a diff to a maximum number of lines
-func truncateDiff(diff string, maxLines int) string {
-	lines := strings.Split(diff, "\n")
-	
-	if len(lines) <= maxLines {
-		return diff
+// truncatePatch cuts a patch to at most maxLines lines and, at a line
+// boundary where possible, maxChars characters. A truncated patch always
+// ends with a note of how many lines were left out.
+func truncatePatch(patch string, maxChars, maxLines int) string {
+	lines := strings.Split(patch, "\n")
+
+	keep := len(lines)
+	if maxLines > 0 && keep > maxLines {
+		keep = maxLines
+	}
+	if maxChars > 0 {
+		size := 0
+		for i := 0; i < keep; i++ {
+			size += len(lines[i]) + 1
+			if size > maxChars {
+				keep = i
+				break
+			}
+		}
+	}
+	if keep == len(lines) {
+		return patch
 	}
 
-	truncated := strings.Join(lines[:maxLines], "\n")
-	truncated += fmt.Sprintf("\n... (truncated %d more lines)", len(lines)-maxLines)
-	
-	return truncated
-}
\ No newline at end of file
+	// A first line longer than maxChars is cut mid-line rather than
+	// sending nothing, backing off to the start of a rune so no
+	// character is split
+	if keep == 0 {
+		cut := maxChars
+		for cut > 0 && cut < len(lines[0]) && !utf8.RuneStart(lines[0][cut]) {
+			cut--
+		}
+		return lines[0][:cut] + fmt.Sprintf("\n... (truncated %d lines)", len(lines))
+	}
+	return strings.Join(lines[:keep], "\n") + fmt.Sprintf("\n... (truncated %d lines)", len(lines)-keep)
+}
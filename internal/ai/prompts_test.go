@@ -0,0 +1,58 @@
+package ai
+
+import (
+	"testing"
+	"unicode/utf8"
+)
+
+func TestAddedOnlyPatch(t *testing.T) {
+	patch := "@@ -1,4 +1,5 @@ package main\n" +
+		" package main\n" +
+		"-var a = 1\n" +
+		"+var a = 2\n" +
+		"+var b = 3\n" +
+		" \n" +
+		"+var c = 4\n" +
+		" var d = 5\n" +
+		"@@ -20,2 +21,2 @@ func main() {\n" +
+		"-\trun()\n" +
+		" }"
+
+	want := "@@ -2,0 +2,2 @@ package main\n" +
+		"+var a = 2\n" +
+		"+var b = 3\n" +
+		"@@ -3,0 +5,1 @@ package main\n" +
+		"+var c = 4"
+	if got := addedOnlyPatch(patch); got != want {
+		t.Errorf("addedOnlyPatch() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestAddedOnlyPatchWithoutAdditions(t *testing.T) {
+	if got := addedOnlyPatch("@@ -1,2 +1,1 @@\n-gone\n kept"); got != "" {
+		t.Errorf("addedOnlyPatch() = %q, want \"\"", got)
+	}
+}
+
+func TestNumberAddedOnlyPatch(t *testing.T) {
+	// Inline comments number the lines of the added-only patch, so they
+	// must still be the lines of the new file
+	patch := "@@ -1,3 +1,4 @@\n one\n+two\n three\n+four"
+
+	want := "@@ -1,0 +2,1 @@\n    2 +two\n@@ -2,0 +4,1 @@\n    4 +four"
+	if got := numberPatch(addedOnlyPatch(patch)); got != want {
+		t.Errorf("numberPatch(addedOnlyPatch()) =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestTruncatePatchKeepsRunesWhole(t *testing.T) {
+	// Each "é" is two bytes, so a 5-byte cut lands inside the third one
+	got := truncatePatch("+ééé\n+next", 5, 0)
+	want := "+éé\n... (truncated 2 lines)"
+	if got != want {
+		t.Errorf("truncatePatch() = %q, want %q", got, want)
+	}
+	if !utf8.ValidString(got) {
+		t.Errorf("truncatePatch() = %q, not valid UTF-8", got)
+	}
+}
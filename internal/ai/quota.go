@@ -0,0 +1,71 @@
+package ai
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/apierr"
+	"google.golang.org/genai"
+)
+
+// ErrQuotaExceeded is returned when the AI provider rejects requests
+// because the account's quota or billing limit is exhausted
+var ErrQuotaExceeded = errors.New("AI quota exceeded")
+
+// cooldown pauses AI review after a quota error so the API isn't called
+// again until the period has passed. It is shared by copies of a
+// fileReviewer.
+type cooldown struct {
+	period time.Duration
+
+	mu    sync.Mutex
+	until time.Time
+}
+
+// active returns when the cooldown ends and whether it is still running
+func (c *cooldown) active() (time.Time, bool) {
+	if c == nil {
+		return time.Time{}, false
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.until, time.Now().Before(c.until)
+}
+
+// trip starts the cooldown
+func (c *cooldown) trip() {
+	if c == nil || c.period <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.until = time.Now().Add(c.period)
+}
+
+// isGeminiQuotaError reports whether err is a Gemini quota or billing error
+func isGeminiQuotaError(err error) bool {
+	var apiErr genai.APIError
+	if !errors.As(err, &apiErr) {
+		return false
+	}
+	message := strings.ToLower(apiErr.Message)
+	switch apiErr.Code {
+	case http.StatusTooManyRequests:
+		return apiErr.Status == "RESOURCE_EXHAUSTED" || strings.Contains(message, "quota")
+	case http.StatusForbidden:
+		return strings.Contains(message, "billing")
+	}
+	return false
+}
+
+// classifyGemini tags a Gemini API error with its apierr kind
+func classifyGemini(err error) error {
+	var apiErr genai.APIError
+	if errors.As(err, &apiErr) {
+		return apierr.Wrap(apierr.FromStatus(apiErr.Code), err)
+	}
+	return apierr.FromNetwork(err)
+}
@@ -0,0 +1,272 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/apierr"
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"golang.org/x/time/rate"
+)
+
+// reviewRetryDelay is how long to wait before retrying a file whose
+// review hit a rate limit or transient error
+const reviewRetryDelay = 2 * time.Second
+
+// ErrNothingToReview is returned when every file of a PR was skipped
+// before reaching the AI, e.g. because none of them is code
+var ErrNothingToReview = errors.New("no files to review")
+
+// fileReviewFunc reviews a single file's (possibly truncated) patch
+type fileReviewFunc func(filename string, patch string, additions, deletions int) (string, error)
+
+// fileReviewer holds the per-file review settings shared by all Reviewer
+// backends
+type fileReviewer struct {
+	promptTemplate *template.Template
+	cache          ReviewCache
+	depth          depthProfile
+	priority       Priority
+	priorityPaths  []string
+	maxFiles       int
+	limiter        *rate.Limiter
+	quota          *cooldown
+	verdict        bool
+	inline         bool
+	languages      []string
+	skipTrivial    bool
+	addedOnly      bool
+}
+
+// newFileReviewer builds the shared review settings from opts
+func newFileReviewer(opts Options) fileReviewer {
+	tmpl, err := ParsePromptTemplate(opts.PromptTemplate)
+	if err != nil {
+		log.Printf("Invalid prompt template, using built-in: %v", err)
+		tmpl, _ = ParsePromptTemplate("")
+	}
+
+	return fileReviewer{
+		promptTemplate: tmpl,
+		cache:          opts.Cache,
+		depth:          profileFor(opts.Depth).withLimits(opts.MaxFilePatchChars, opts.MaxFilePatchLines),
+		priority:       opts.Priority,
+		priorityPaths:  opts.PriorityPaths,
+		maxFiles:       opts.MaxFiles,
+		limiter:        opts.Limiter,
+		quota:          &cooldown{period: opts.QuotaCooldown},
+		verdict:        opts.Verdict,
+		inline:         opts.InlineComments,
+		languages:      opts.Languages,
+		skipTrivial:    opts.SkipTrivial,
+		addedOnly:      opts.AddedLinesOnly,
+	}
+}
+
+// renderPrompt renders the per-file prompt, filling in the file's language
+// and the instructions for the configured review depth
+func (r fileReviewer) renderPrompt(data FilePromptData) (string, error) {
+	data.Language = languageFor(data.Filename)
+	data.Instructions = r.depth.instructions
+	if r.verdict {
+		data.Instructions += verdictInstruction
+	}
+	if r.inline {
+		data.Instructions += inlineInstruction
+		data.Patch = numberPatch(data.Patch)
+	}
+	return renderFilePrompt(r.promptTemplate, data)
+}
+
+// reviewByFile reviews each file individually with review and combines
+// the results in priority order. Reviews found in the cache are reused
+// instead of calling the API. Rate limit waits and retries stop when ctx
+// is done.
+func (r fileReviewer) reviewByFile(ctx context.Context, rc models.ReviewContext, review fileReviewFunc) (models.ReviewResult, error) {
+	if until, paused := r.quota.active(); paused {
+		return models.ReviewResult{}, fmt.Errorf("%w: AI review paused until %s", ErrQuotaExceeded, until.Format(time.Kitchen))
+	}
+
+	var allReviews strings.Builder
+
+	allReviews.WriteString(fmt.Sprintf("**PR Review for #%d: %s**\n\n", rc.PullRequest.Number, rc.PullRequest.Title))
+
+	filesReviewed := 0
+	var failed []string
+	cacheHits := 0
+	filesSkipped := 0
+	nonCode := 0
+	otherLanguage := 0
+	trivial := 0
+	verdict := ""
+	var comments []models.ReviewComment
+
+	files := SortFiles(rc.DiffFiles, r.priority, r.priorityPaths)
+
+	// Review each file individually
+	for i, file := range files {
+		// Skip binary files or files without patches
+		if file.Patch == "" {
+			continue
+		}
+
+		// Images, lockfiles and bundles aren't worth the tokens
+		if SkipReview(file.Filename) {
+			nonCode++
+			continue
+		}
+
+		// Only the languages the team asked for are worth a review
+		if !InLanguages(file.Filename, r.languages) {
+			otherLanguage++
+			continue
+		}
+
+		// Reformatting and comment edits cost as much to review as code
+		if r.skipTrivial && TrivialChange(file.Filename, file.Patch) {
+			trivial++
+			continue
+		}
+
+		// Files are sorted by priority, so the budget drops the least
+		// important ones
+		if r.maxFiles > 0 && filesReviewed+len(failed) >= r.maxFiles {
+			filesSkipped++
+			continue
+		}
+
+		// A file that only lost lines has nothing added to review
+		patch := file.Patch
+		if r.addedOnly {
+			if patch = addedOnlyPatch(patch); patch == "" {
+				continue
+			}
+		}
+
+		// Truncate very large diffs
+		patch = truncatePatch(patch, r.depth.maxPatchChars, r.depth.maxPatchLines)
+
+		cacheKey := ReviewCacheKey(file.Filename, patch)
+		if r.cache != nil && !rc.Fresh {
+			if cached, ok := r.cache.Get(cacheKey); ok {
+				log.Printf("Using cached review for file %d/%d: %s", i+1, len(files), file.Filename)
+				if r.verdict {
+					var fileVerdict string
+					fileVerdict, cached = parseVerdict(cached)
+					verdict = worseVerdict(verdict, fileVerdict)
+				}
+				if r.inline {
+					comments = append(comments, parseInlineComments(file.Filename, cached)...)
+				}
+				allReviews.WriteString(fmt.Sprintf("\n### %s\n", file.Filename))
+				allReviews.WriteString(cached)
+				allReviews.WriteString("\n\n")
+				filesReviewed++
+				cacheHits++
+				continue
+			}
+		}
+
+		// Wait for the rate limiter before calling the API
+		if r.limiter != nil {
+			if err := r.limiter.Wait(ctx); err != nil {
+				return models.ReviewResult{}, fmt.Errorf("failed to wait for rate limiter: %w", err)
+			}
+		}
+
+		log.Printf("Reviewing file %d/%d: %s", i+1, len(files), file.Filename)
+
+		fileReview, err := review(file.Filename, patch, file.Additions, file.Deletions)
+		if apierr.Retryable(err) && !errors.Is(err, ErrQuotaExceeded) {
+			log.Printf("Retrying review of %s after %v: %v", file.Filename, reviewRetryDelay, err)
+			select {
+			case <-time.After(reviewRetryDelay):
+			case <-ctx.Done():
+				return models.ReviewResult{}, ctx.Err()
+			}
+			fileReview, err = review(file.Filename, patch, file.Additions, file.Deletions)
+		}
+		if errors.Is(err, apierr.ErrAuth) {
+			// Every remaining file would fail the same way
+			log.Printf("🚨 AI provider rejected the API key, stopping AI review: %v", err)
+			return models.ReviewResult{}, err
+		}
+		if errors.Is(err, ErrQuotaExceeded) {
+			// Every remaining file would fail the same way
+			log.Printf("🚨 AI quota exceeded, pausing AI review: %v", err)
+			r.quota.trip()
+			return models.ReviewResult{}, err
+		}
+		if err != nil {
+			log.Printf("Failed to review %s: %v", file.Filename, err)
+			allReviews.WriteString(fmt.Sprintf("\n### %s\n", file.Filename))
+			allReviews.WriteString("_Could not review this file due to API error_\n\n")
+			failed = append(failed, file.Filename)
+			continue
+		}
+
+		// The cache keeps the verdict line so cached reviews still count
+		if r.cache != nil {
+			r.cache.Set(cacheKey, fileReview)
+		}
+		if r.verdict {
+			var fileVerdict string
+			fileVerdict, fileReview = parseVerdict(fileReview)
+			verdict = worseVerdict(verdict, fileVerdict)
+		}
+		if r.inline {
+			comments = append(comments, parseInlineComments(file.Filename, fileReview)...)
+		}
+
+		allReviews.WriteString(fmt.Sprintf("\n### %s\n", file.Filename))
+		allReviews.WriteString(fileReview)
+		allReviews.WriteString("\n\n")
+		filesReviewed++
+	}
+
+	if filesReviewed == 0 && len(failed) == 0 {
+		return models.ReviewResult{}, fmt.Errorf("%w: %d non-code, %d in other languages, %d trivial", ErrNothingToReview, nonCode, otherLanguage, trivial)
+	}
+	if filesReviewed == 0 {
+		return models.ReviewResult{}, fmt.Errorf("failed to review any files (%d failed)", len(failed))
+	}
+
+	// Add overall summary
+	allReviews.WriteString("\n---\n")
+	allReviews.WriteString(fmt.Sprintf("**Summary:** Reviewed %d/%d file(s) successfully",
+		filesReviewed,
+		len(rc.DiffFiles)))
+	if cacheHits > 0 {
+		allReviews.WriteString(fmt.Sprintf(" (%d unchanged file(s) from cache)", cacheHits))
+	}
+	if len(failed) > 0 {
+		allReviews.WriteString(fmt.Sprintf(", %d file(s) failed", len(failed)))
+	}
+	if nonCode > 0 {
+		allReviews.WriteString(fmt.Sprintf(", %d non-code file(s) skipped", nonCode))
+	}
+	if otherLanguage > 0 {
+		allReviews.WriteString(fmt.Sprintf(", %d file(s) in other languages skipped", otherLanguage))
+	}
+	if trivial > 0 {
+		allReviews.WriteString(fmt.Sprintf(", %d file(s) with no substantive changes skipped", trivial))
+	}
+	if filesSkipped > 0 {
+		allReviews.WriteString(fmt.Sprintf(", %d lower-priority file(s) skipped over the %d-file budget", filesSkipped, r.maxFiles))
+	}
+	allReviews.WriteString("\n")
+
+	return models.ReviewResult{
+		ReviewText: allReviews.String(),
+		Reviewed:   filesReviewed,
+		Failed:     failed,
+		Skipped:    filesSkipped,
+		Verdict:    verdict,
+		Comments:   comments,
+	}, nil
+}
@@ -0,0 +1,33 @@
+package ai
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/Rishav176/GitReviewed/internal/apierr"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+func TestReviewByFileStopsRetryWhenContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	review := func(filename, patch string, additions, deletions int) (string, error) {
+		calls++
+		return "", fmt.Errorf("%w: upstream unavailable", apierr.ErrTransient)
+	}
+	rc := models.ReviewContext{
+		DiffFiles: []models.DiffFile{{Filename: "main.go", Patch: "@@ -1 +1 @@\n-var a = 1\n+var a = 2", Additions: 1, Deletions: 1}},
+	}
+
+	_, err := newFileReviewer(Options{}).reviewByFile(ctx, rc, review)
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("reviewByFile() error = %v, want %v", err, context.Canceled)
+	}
+	if calls != 1 {
+		t.Errorf("review called %d times, want 1", calls)
+	}
+}
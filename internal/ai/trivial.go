@@ -0,0 +1,131 @@
+package ai
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/Rishav176/GitReviewed/internal/diff"
+)
+
+// lineComments lists the line comment markers of each language, keyed by
+// the names languageFor returns
+var lineComments = map[string][]string{
+	"Go":                 {"//"},
+	"JavaScript":         {"//"},
+	"JavaScript (React)": {"//"},
+	"TypeScript":         {"//"},
+	"TypeScript (React)": {"//"},
+	"Java":               {"//"},
+	"Kotlin":             {"//"},
+	"Scala":              {"//"},
+	"C#":                 {"//"},
+	"C":                  {"//"},
+	"C++":                {"//"},
+	"Rust":               {"//"},
+	"Swift":              {"//"},
+	"Objective-C":        {"//"},
+	"Dart":               {"//"},
+	"SCSS":               {"//"},
+	"PHP":                {"//", "#"},
+	"Terraform":          {"#", "//"},
+	"Python":             {"#"},
+	"Ruby":               {"#"},
+	"Shell":              {"#"},
+	"YAML":               {"#"},
+	"TOML":               {"#"},
+	"Elixir":             {"#"},
+	"Dockerfile":         {"#"},
+	"Makefile":           {"#"},
+	"SQL":                {"--"},
+	"Lua":                {"--"},
+}
+
+// blockCommentLanguages use /* ... */ comments
+var blockCommentLanguages = map[string]bool{
+	"Go": true, "JavaScript": true, "JavaScript (React)": true,
+	"TypeScript": true, "TypeScript (React)": true, "Java": true,
+	"Kotlin": true, "Scala": true, "C#": true, "C": true, "C++": true,
+	"Rust": true, "Swift": true, "Objective-C": true, "Dart": true,
+	"CSS": true, "SCSS": true, "PHP": true, "SQL": true, "Terraform": true,
+}
+
+// indentedLanguages give meaning to indentation, so it counts as a change
+var indentedLanguages = map[string]bool{
+	"Python":   true,
+	"YAML":     true,
+	"Makefile": true,
+}
+
+// TrivialChange reports whether a patch only changes whitespace or
+// comments, so an AI review has nothing substantive to look at. Only
+// whole-line comments are recognized, so a comment added after code
+// still counts as a change; files of unknown language only skip
+// whitespace changes.
+func TrivialChange(filename, patch string) bool {
+	if patch == "" {
+		return false
+	}
+
+	lang := languageFor(filename)
+	var removed, added []string
+	for _, line := range diff.Parse(patch).Lines() {
+		switch line.Kind {
+		case diff.Removed:
+			removed = append(removed, codeOf(lang, line.Text))
+		case diff.Added:
+			added = append(added, codeOf(lang, line.Text))
+		}
+	}
+
+	// Joining lets a statement rewrapped over several lines compare
+	// equal to the original
+	sep := ""
+	if indentedLanguages[lang] {
+		sep = "\n"
+	}
+	return joinCode(removed, sep) == joinCode(added, sep)
+}
+
+// codeOf returns the code on a line with comment lines emptied and
+// insignificant whitespace removed
+func codeOf(lang, text string) string {
+	trimmed := strings.TrimSpace(text)
+	for _, marker := range lineComments[lang] {
+		if strings.HasPrefix(trimmed, marker) {
+			return ""
+		}
+	}
+	if blockCommentLanguages[lang] && isBlockCommentLine(trimmed) {
+		return ""
+	}
+
+	if indentedLanguages[lang] {
+		return strings.TrimRightFunc(text, unicode.IsSpace)
+	}
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, text)
+}
+
+// isBlockCommentLine reports whether a trimmed line is part of a
+// /* ... */ comment, such as its opening line or a " * text" line
+func isBlockCommentLine(trimmed string) bool {
+	if strings.HasPrefix(trimmed, "/*") {
+		return !strings.Contains(trimmed, "*/") || strings.HasSuffix(trimmed, "*/")
+	}
+	return trimmed == "*" || trimmed == "*/" || strings.HasPrefix(trimmed, "* ")
+}
+
+// joinCode joins the non-empty lines of code with sep
+func joinCode(lines []string, sep string) string {
+	var kept []string
+	for _, line := range lines {
+		if line != "" {
+			kept = append(kept, line)
+		}
+	}
+	return strings.Join(kept, sep)
+}
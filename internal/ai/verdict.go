@@ -0,0 +1,56 @@
+package ai
+
+import (
+	"strings"
+)
+
+// Verdicts the model can give when Options.Verdict is set
+const (
+	VerdictApprove        = "approve"
+	VerdictComment        = "comment"
+	VerdictRequestChanges = "request-changes"
+)
+
+// verdictTag starts the machine-readable first line of a review
+const verdictTag = "VERDICT:"
+
+// verdictInstruction is appended to the review instructions when verdicts
+// are requested
+const verdictInstruction = `
+6. Start your reply with exactly one line "VERDICT: approve", "VERDICT: comment" or "VERDICT: request-changes". Only request changes for bugs, security issues or data loss`
+
+// verdictRank orders verdicts from least to most severe
+var verdictRank = map[string]int{
+	VerdictApprove:        1,
+	VerdictComment:        2,
+	VerdictRequestChanges: 3,
+}
+
+// parseVerdict splits the verdict line off a review. A missing or
+// unrecognized verdict is treated as a comment, so the model can't approve
+// a change by leaving the tag out.
+func parseVerdict(review string) (string, string) {
+	trimmed := strings.TrimLeft(review, " \t\r\n")
+	firstLine, rest, _ := strings.Cut(trimmed, "\n")
+
+	line := strings.TrimSpace(strings.Trim(firstLine, "*_` \t\r"))
+	if len(line) < len(verdictTag) || !strings.EqualFold(line[:len(verdictTag)], verdictTag) {
+		return VerdictComment, review
+	}
+
+	verdict := strings.ToLower(strings.Trim(line[len(verdictTag):], "*_` \t"))
+	verdict = strings.ReplaceAll(verdict, "_", "-")
+	if _, ok := verdictRank[verdict]; !ok {
+		verdict = VerdictComment
+	}
+	return verdict, strings.TrimLeft(rest, "\r\n")
+}
+
+// worseVerdict returns the more severe of two verdicts; an empty verdict
+// counts as none
+func worseVerdict(a, b string) string {
+	if verdictRank[b] > verdictRank[a] {
+		return b
+	}
+	return a
+}
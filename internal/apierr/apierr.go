@@ -0,0 +1,75 @@
+// Package apierr defines the kinds of failure shared by the GitHub, AI and
+// Slack clients so callers can branch on them with errors.Is, e.g. to
+// retry transient failures and fail fast on bad credentials.
+package apierr
+
+import (
+	"errors"
+	"net"
+	"net/http"
+)
+
+var (
+	// ErrRateLimited means the API rejected the call for exceeding a rate
+	// limit
+	ErrRateLimited = errors.New("rate limited")
+
+	// ErrAuth means the credentials are missing, invalid or lack the
+	// required permission
+	ErrAuth = errors.New("authentication failed")
+
+	// ErrTransient means a server or network failure that may succeed if
+	// retried
+	ErrTransient = errors.New("transient failure")
+)
+
+// kindError tags an error with its kind without changing its message
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.err.Error()
+}
+
+func (e *kindError) Unwrap() []error {
+	return []error{e.kind, e.err}
+}
+
+// Wrap tags err with kind. It returns err unchanged when either is nil.
+func Wrap(kind, err error) error {
+	if kind == nil || err == nil {
+		return err
+	}
+	return &kindError{kind: kind, err: err}
+}
+
+// FromStatus returns the kind of failure an HTTP status code signals, or
+// nil when it isn't one of the shared kinds
+func FromStatus(code int) error {
+	switch {
+	case code == http.StatusUnauthorized || code == http.StatusForbidden:
+		return ErrAuth
+	case code == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case code >= http.StatusInternalServerError:
+		return ErrTransient
+	}
+	return nil
+}
+
+// FromNetwork tags network failures such as timeouts and refused
+// connections as transient
+func FromNetwork(err error) error {
+	var netErr net.Error
+	if errors.As(err, &netErr) {
+		return Wrap(ErrTransient, err)
+	}
+	return err
+}
+
+// Retryable reports whether err is a rate limit or transient failure
+func Retryable(err error) bool {
+	return errors.Is(err, ErrRateLimited) || errors.Is(err, ErrTransient)
+}
@@ -0,0 +1,54 @@
+// Package approval tracks blocked PRs whose block a security reviewer must
+// approve before their status can pass again
+package approval
+
+import "sync"
+
+// Store remembers the PRs awaiting approval, keyed by
+// "owner/name#number". Implementations must be safe for concurrent use.
+type Store interface {
+	// Block marks key as needing approval, discarding an earlier approval
+	Block(key string) error
+
+	// Awaiting reports whether key still needs approval
+	Awaiting(key string) bool
+
+	// Clear drops key and reports whether it needed approval
+	Clear(key string) (bool, error)
+}
+
+// MemoryStore keeps pending approvals in memory; they are lost on restart
+type MemoryStore struct {
+	mu      sync.Mutex
+	pending map[string]bool
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{pending: make(map[string]bool)}
+}
+
+// Block marks key as needing approval
+func (m *MemoryStore) Block(key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.pending[key] = true
+	return nil
+}
+
+// Awaiting reports whether key still needs approval
+func (m *MemoryStore) Awaiting(key string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.pending[key]
+}
+
+// Clear drops key and reports whether it needed approval
+func (m *MemoryStore) Clear(key string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	was := m.pending[key]
+	delete(m.pending, key)
+	return was, nil
+}
@@ -0,0 +1,97 @@
+package approval
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/Rishav176/GitReviewed/internal/atomicfile"
+)
+
+// FileStore keeps pending approvals in a JSON file so blocks survive a
+// restart. The file is rewritten on every change.
+type FileStore struct {
+	path string
+
+	mu      sync.Mutex
+	pending map[string]bool
+}
+
+// stateFile is the layout of a FileStore's file
+type stateFile struct {
+	Pending []string `json:"pending"`
+}
+
+// NewFileStore loads the pending approvals saved at path, if any
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{path: path, pending: make(map[string]bool)}
+
+	data, err := atomicfile.Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read approval state: %w", err)
+	}
+	if data == nil {
+		return s, nil
+	}
+
+	var state stateFile
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse approval state %s: %w", path, err)
+	}
+	for _, key := range state.Pending {
+		s.pending[key] = true
+	}
+	return s, nil
+}
+
+// Block marks key as needing approval and saves the change
+func (s *FileStore) Block(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.pending[key] {
+		return nil
+	}
+	s.pending[key] = true
+	return s.save()
+}
+
+// Awaiting reports whether key still needs approval
+func (s *FileStore) Awaiting(key string) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.pending[key]
+}
+
+// Clear drops key, saves the change and reports whether key needed
+// approval
+func (s *FileStore) Clear(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.pending[key] {
+		return false, nil
+	}
+	delete(s.pending, key)
+	return true, s.save()
+}
+
+// save replaces the file with the pending keys. The caller holds s.mu.
+func (s *FileStore) save() error {
+	state := stateFile{Pending: make([]string, 0, len(s.pending))}
+	for key := range s.pending {
+		state.Pending = append(state.Pending, key)
+	}
+	sort.Strings(state.Pending)
+
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode approval state: %w", err)
+	}
+
+	if err := atomicfile.Write(s.path, append(data, '\n'), 0o600, nil); err != nil {
+		return fmt.Errorf("failed to save approval state: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,57 @@
+// Package atomicfile reads and replaces the small files GitReviewed
+// rewrites at runtime, such as the pattern file and saved state, so a crash
+// mid-write never leaves a truncated file behind.
+package atomicfile
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// Read returns the contents of the file at path, or nil when it doesn't
+// exist yet. A missing file reads as empty and is created by the first
+// Write.
+func Read(path string) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	return data, err
+}
+
+// Write replaces the file at path with data. The data is written to a
+// temporary file next to it, so the final rename is atomic, and keeps the
+// mode of the file it replaces, or perm for a new file. check, when set,
+// vets the temporary file first; its error is returned as is and leaves
+// the file untouched.
+func Write(path string, data []byte, perm os.FileMode, check func(name string) error) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if info, err := os.Stat(path); err == nil {
+		perm = info.Mode().Perm()
+	}
+	if err := os.Chmod(tmp.Name(), perm); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if check != nil {
+		if err := check(tmp.Name()); err != nil {
+			return err
+		}
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("failed to replace %s: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package atomicfile
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestWriteReplacesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+
+	if data, err := Read(path); err != nil || data != nil {
+		t.Fatalf("Read of a missing file = %q, %v; want nil, nil", data, err)
+	}
+	if err := Write(path, []byte("one"), 0o600, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+	if err := os.Chmod(path, 0o640); err != nil {
+		t.Fatal(err)
+	}
+	if err := Write(path, []byte("two"), 0o600, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	if data, err := Read(path); err != nil || string(data) != "two" {
+		t.Errorf("Read = %q, %v; want %q", data, err, "two")
+	}
+	if info, err := os.Stat(path); err != nil || info.Mode().Perm() != 0o640 {
+		t.Errorf("mode after replacing = %v, %v; want the file's own 0640", info.Mode().Perm(), err)
+	}
+	if leftover, _ := filepath.Glob(path + ".*.tmp"); len(leftover) > 0 {
+		t.Errorf("temporary files left behind: %v", leftover)
+	}
+}
+
+func TestWriteKeepsFileWhenCheckFails(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "patterns.yaml")
+	if err := Write(path, []byte("good"), 0o644, nil); err != nil {
+		t.Fatalf("Write: %v", err)
+	}
+
+	errInvalid := errors.New("invalid")
+	err := Write(path, []byte("bad"), 0o644, func(name string) error {
+		if data, _ := os.ReadFile(name); string(data) != "bad" {
+			t.Errorf("check read %q, want the new data", data)
+		}
+		return errInvalid
+	})
+	if !errors.Is(err, errInvalid) {
+		t.Errorf("Write error = %v, want the check's error", err)
+	}
+	if data, _ := Read(path); string(data) != "good" {
+		t.Errorf("file = %q after a failed check, want it unchanged", data)
+	}
+}
@@ -0,0 +1,68 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// Actions recorded in the audit log
+const (
+	// ActionBlocked is recorded when a failure status blocks a PR
+	ActionBlocked = "blocked"
+	// ActionCleared is recorded when a previously blocked PR passes
+	ActionCleared = "cleared"
+	// ActionApproved is recorded when a security reviewer approves
+	// clearing a block
+	ActionApproved = "approved"
+)
+
+// Event is a single audit record of a blocking decision
+type Event struct {
+	Time       time.Time `json:"time"`
+	Action     string    `json:"action"`
+	Repository string    `json:"repository"`
+	PRNumber   int       `json:"pr_number"`
+	SHA        string    `json:"sha"`
+	Threshold  string    `json:"threshold"`
+	// Actor is who approved a block; only set for ActionApproved
+	Actor    string    `json:"actor,omitempty"`
+	Findings []Finding `json:"findings"`
+}
+
+// Finding describes a blocking finding without the secret itself
+type Finding struct {
+	Type        string `json:"type"`
+	Severity    string `json:"severity"`
+	Original    string `json:"original_severity,omitempty"`
+	File        string `json:"file,omitempty"`
+	Commit      string `json:"commit,omitempty"`
+	Line        int    `json:"line"`
+	Cell        int    `json:"cell,omitempty"`
+	Fingerprint string `json:"fingerprint"`
+}
+
+// Logger records blocking decisions. Implementations must be safe for
+// concurrent use and must not modify past records.
+type Logger interface {
+	Record(ctx context.Context, event Event) error
+}
+
+// Findings converts security issues to audit findings
+func Findings(issues []models.SecurityIssue) []Finding {
+	findings := make([]Finding, 0, len(issues))
+	for _, issue := range issues {
+		findings = append(findings, Finding{
+			Type:        issue.Type,
+			Severity:    issue.Severity,
+			Original:    issue.OriginalSeverity,
+			File:        issue.FilePath,
+			Commit:      issue.CommitSHA,
+			Line:        issue.LineNumber,
+			Cell:        issue.Cell,
+			Fingerprint: issue.Fingerprint,
+		})
+	}
+	return findings
+}
@@ -2,7 +2,17 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/ai"
+	"github.com/Rishav176/GitReviewed/internal/pathmatch"
+	"gopkg.in/yaml.v3"
 )
 
 // Config holds all application configuration
@@ -11,12 +21,327 @@ type Config struct {
 	GitHubToken   string
 	WebhookSecret string
 
+	// GitHubBaseURL is the API root of a GitHub Enterprise Server
+	// instance, e.g. https://ghe.company.com/api/v3; empty uses github.com
+	GitHubBaseURL string
+
+	// WebhookCheckUserAgent rejects webhooks whose User-Agent isn't
+	// GitHub's "GitHub-Hookshot/..."
+	WebhookCheckUserAgent bool
+
+	// WebhookMaxBytes is the largest webhook body read; larger requests
+	// are rejected before their signature is checked
+	WebhookMaxBytes int
+
 	// Slack configuration
 	SlackToken   string
 	SlackChannel string
 
+	// Slack alert wording; severity labels are only set from ConfigFile
+	SlackDisableEmoji   bool
+	SlackActionText     string
+	SlackSeverityLabels map[string]SeverityLabel
+	SlackMaxIssues      int
+
+	// SlackMentions maps severities to who is mentioned in alerts whose
+	// most severe finding is at that severity: "@here", "@channel", a
+	// user group ID ("S...") or a user ID ("U..."). Severities without an
+	// entry mention no one.
+	SlackMentions map[string]string
+
+	// SlackMaxBlocks caps the blocks in a single alert message; the rest
+	// of the alert is posted in its thread
+	SlackMaxBlocks int
+
+	// Digest batches PR alerts whose findings are all below
+	// DigestImmediateSeverity into a summary of the open PRs with
+	// findings, posted daily at DigestTime ("15:04", server local time)
+	Digest                  bool
+	DigestTime              string
+	DigestImmediateSeverity string
+	// DigestStateFile keeps the findings waiting for the digest across
+	// restarts; when empty they are kept in memory
+	DigestStateFile string
+
+	// NotifyOnClean sends the review complete message and AI review to
+	// Slack for PRs without findings; statuses are posted either way
+	NotifyOnClean bool
+
+	// SlackMaxReviewChars caps the AI review posted to Slack; with
+	// SlackUploadFullReview the full review is uploaded as a file
+	SlackMaxReviewChars   int
+	SlackUploadFullReview bool
+
+	// SlackChannels routes notification types to channels, keyed by route
+	// ("security", "security_critical", "push", "ai_review", ...)
+	SlackChannels map[string]string
+
 	// AI configuration
-	GeminiAPIKey string  // CHANGED FROM AnthropicAPIKey
+	AIProvider   string // "gemini" or "openai"
+	GeminiAPIKey string // CHANGED FROM AnthropicAPIKey
+	OpenAIAPIKey string
+	OpenAIModel  string
+
+	// DisableAIReview runs GitReviewed as a secret scanner only: no AI
+	// client is created and no AI keys are required, unless a repository
+	// in Repos turns AI review back on
+	DisableAIReview bool
+
+	// ReviewDepth is "brief", "standard" or "thorough"
+	ReviewDepth string
+
+	// ReviewMaxPatchChars and ReviewMaxPatchLines cap the patch sent to
+	// the AI for each file; 0 keeps the budget of ReviewDepth
+	ReviewMaxPatchChars int
+	ReviewMaxPatchLines int
+
+	// ReviewCacheTTL is how long per-file reviews are reused; 0 disables
+	// the cache
+	ReviewCacheTTL time.Duration
+
+	// ReviewPriority orders files before review: "additions", "path",
+	// "alpha" or empty for GitHub's order. ReviewPriorityPaths are the
+	// globs used by "path", most important first.
+	ReviewPriority      string
+	ReviewPriorityPaths []string
+
+	// ReviewMaxFiles caps how many files are reviewed, and kept in memory
+	// for the review, per PR; larger PRs are still fully scanned for
+	// secrets. 0 means no limit.
+	ReviewMaxFiles int
+
+	// ReviewSkipLines skips AI review of PRs changing more lines than
+	// this; the secret scan still runs. 0 means no limit.
+	ReviewSkipLines int
+
+	// MinReviewChanges skips AI review of PRs changing fewer lines of code
+	// than this, such as typo fixes; MinReviewNotify says so in Slack.
+	// 0 means no minimum.
+	MinReviewChanges int
+	MinReviewNotify  bool
+
+	// ReviewSkipAuthors lists PR author logins whose PRs are scanned but
+	// not AI-reviewed. When ReviewOnlyAuthors is set, only its authors
+	// are AI-reviewed.
+	ReviewSkipAuthors []string
+	ReviewOnlyAuthors []string
+
+	// ReviewLanguages limits AI review to files in these languages, e.g.
+	// "Go,Python"; files in other languages are still scanned for
+	// secrets. Empty reviews every language.
+	ReviewLanguages []string
+
+	// ReviewSkipTrivial leaves files whose changes are only whitespace or
+	// comments out of the AI review
+	ReviewSkipTrivial bool
+
+	// ReviewAddedLinesOnly sends the AI only the added lines of each
+	// patch rather than the whole patch
+	ReviewAddedLinesOnly bool
+
+	// AIRequestsPerMinute limits AI API requests; 0 disables the limit
+	AIRequestsPerMinute int
+
+	// AIQuotaCooldown pauses AI review after the provider reports an
+	// exhausted quota
+	AIQuotaCooldown time.Duration
+
+	// AIBreakerFailures consecutive failed reviews pause AI review for
+	// AIBreakerCooldown; 0 disables the circuit breaker
+	AIBreakerFailures int
+	AIBreakerCooldown time.Duration
+
+	// AIStatus posts a gitreviewed/ai-review commit status; it reports
+	// "error" when at least AIFailurePercent of the reviewed files failed
+	AIStatus         bool
+	AIFailurePercent int
+
+	// AIReviewGate asks the model for a verdict and fails the
+	// gitreviewed/ai-review status when it requests changes, so branch
+	// protection can require AI sign-off. It implies AIStatus.
+	AIReviewGate bool
+
+	// VerdictStatus posts a gitreviewed/verdict status combining the
+	// secret scan and, with AIReviewGate, the AI review's verdict
+	VerdictStatus bool
+
+	// AIInlineComments asks the model for line references and posts them
+	// as inline review comments on the PR's diff
+	AIInlineComments bool
+
+	// ReviewPromptTemplate is a text/template used for per-file AI reviews.
+	// It is read from REVIEW_PROMPT_FILE when set, otherwise from
+	// REVIEW_PROMPT_TEMPLATE. Empty means the built-in template is used.
+	ReviewPromptTemplate string
+
+	// Scanner configuration
+	PatternsFile string
+	BaselineFile string
+
+	// SeverityRules adjust finding severities by path before the blocking
+	// decision; the first matching rule applies
+	SeverityRules []SeverityRule
+
+	// Projects splits a monorepo into sub-projects with their own Slack
+	// channel and block threshold; files outside every project use the
+	// global settings. Only set from CONFIG_FILE.
+	Projects []Project
+
+	// ScanConcurrency is how many files are scanned in parallel
+	ScanConcurrency int
+
+	// ScanRenamedFiles fetches and scans the full content of files that
+	// were renamed without changes, which GitHub sends without a patch
+	ScanRenamedFiles bool
+
+	// SkipGeneratedFiles leaves files marked linguist-generated in the
+	// repository's .gitattributes out of the AI review, and
+	// GeneratedDowngrade lowers the severity of findings in them by that
+	// many levels. The attributes are re-read every GeneratedFilesTTL.
+	SkipGeneratedFiles bool
+	GeneratedDowngrade int
+	GeneratedFilesTTL  time.Duration
+
+	// SensitiveFileSeverity is reported when a credentials file such as
+	// .env is committed; "OFF" disables the check
+	SensitiveFileSeverity string
+
+	// ScanBase64 also decodes long base64 runs in added lines and scans
+	// their content
+	ScanBase64 bool
+
+	// ScanSkipExtensions are file extensions that are never scanned; nil
+	// uses the scanner's defaults and an empty list scans every file
+	ScanSkipExtensions []string
+
+	// DisabledPatterns lists pattern names that are never scanned for
+	DisabledPatterns []string
+
+	// Repos overrides settings per repository, keyed by lowercase
+	// "owner/name"; it is only set from ConfigFile. See ForRepo.
+	Repos map[string]RepoConfig
+
+	// VerifySecrets checks supported secret types against their provider
+	// APIs, at most VerifyRequestsPerMinute calls
+	VerifySecrets           bool
+	VerifyRequestsPerMinute int
+
+	// PushBranches lists branches whose direct pushes are scanned
+	PushBranches []string
+
+	// PushIssues opens a remediation issue when blocking secrets are
+	// pushed to a watched branch
+	PushIssues      bool
+	PushIssueLabels []string
+
+	// Mutable settings; ConfigFile values override the environment and
+	// can be re-read at runtime via Reload
+	ConfigFile    string
+	BlockSeverity string
+
+	// StatusStates maps finding severities to the commit status state
+	// ("failure", "pending" or "success"); the most restrictive state of
+	// any finding is posted. Findings at or above BlockSeverity fail the
+	// status either way.
+	StatusStates map[string]string
+
+	// RequireBlockApproval keeps a blocked PR failing after its secrets
+	// are removed until one of BlockApprovers comments
+	// "/gitreviewed approve" or an operator approves it via POST /approve
+	RequireBlockApproval bool
+	BlockApprovers       []string
+
+	// ApprovalStateFile keeps the PRs awaiting approval across restarts;
+	// when empty they are kept in memory
+	ApprovalStateFile string
+
+	// Organization policy, read from OrgPolicyFile or from OrgPolicyPath
+	// in the OrgPolicyRepo ("owner/name") repository, and re-read every
+	// OrgPolicyTTL
+	OrgPolicyFile string
+	OrgPolicyRepo string
+	OrgPolicyPath string
+	OrgPolicyTTL  time.Duration
+
+	// PostMergeCommitStatus also posts statuses to the PR's test merge
+	// commit, for branch protection rules that evaluate it
+	PostMergeCommitStatus bool
+
+	// StatusTargetURL is the link behind PR commit statuses, with
+	// {owner}, {repo}, {number} and {sha} filled in; empty links to the PR
+	StatusTargetURL string
+
+	// PRComment keeps a single summary comment updated on each PR
+	PRComment bool
+
+	// PRReview submits a formal review requesting changes when a PR is
+	// blocked. PRReviewCleanEvent ("approve", "comment" or "none") picks
+	// the review submitted when a PR is not blocked.
+	PRReview           bool
+	PRReviewCleanEvent string
+
+	// StartupScan runs the PR pipeline for every open PR in
+	// StartupScanRepos ("owner/name") when the server starts, at most
+	// StartupScanConcurrency at a time
+	StartupScan            bool
+	StartupScanRepos       []string
+	StartupScanConcurrency int
+
+	// GitHubAccessCheck verifies the token's access at startup and
+	// refuses to start without it, checking GitHubAccessCheckRepos
+	// (default StartupScanRepos)
+	GitHubAccessCheck      bool
+	GitHubAccessCheckRepos []string
+
+	// HTTPTimeout bounds each GitHub and Slack API call; AITimeout bounds
+	// AI API calls, which take longer
+	HTTPTimeout time.Duration
+	AITimeout   time.Duration
+
+	// OutboundProxy routes all GitHub, Slack, AI and verification calls
+	// through this proxy URL instead of HTTPS_PROXY/HTTP_PROXY, except
+	// for hosts in OutboundNoProxy (default NO_PROXY)
+	OutboundProxy   string
+	OutboundNoProxy []string
+
+	// AuthorHistory tracks findings per PR author so alerts can flag
+	// repeat offenders; entries older than AuthorHistoryRetention are
+	// dropped
+	AuthorHistory          bool
+	AuthorHistoryRetention time.Duration
+
+	// HistorySize is how many processed webhook events GET /history
+	// keeps; 0 disables it
+	HistorySize int
+
+	// AuditLogFile receives a JSON line for every PR that is blocked or
+	// unblocked; empty disables the audit log
+	AuditLogFile string
+
+	// FindingsFile receives a JSON line for every processed PR with its
+	// findings and outcome; empty disables it. It is rotated once it
+	// would pass FindingsFileMaxMB, keeping FindingsFileBackups old files.
+	FindingsFile        string
+	FindingsFileMaxMB   int
+	FindingsFileBackups int
+
+	// SyncMode processes pull_request webhooks before responding and
+	// returns a JSON summary, waiting at most SyncTimeout. Requests can
+	// also opt in with ?sync=true.
+	SyncMode    bool
+	SyncTimeout time.Duration
+
+	// PRDebounce waits this long after a pull_request event before
+	// processing it; newer events for the same PR within the window
+	// replace it, so rapid pushes are reviewed once. 0 disables it.
+	PRDebounce time.Duration
+
+	// ScanDrafts processes draft PRs on every push; when false they are
+	// skipped until marked ready for review
+	ScanDrafts bool
+
+	// AdminToken protects operator endpoints such as /reload
+	AdminToken string
 
 	// Application configuration
 	Environment string
@@ -24,16 +349,272 @@ type Config struct {
 	LogLevel    string
 }
 
+// SeverityLabel overrides how a severity is shown in Slack alerts
+type SeverityLabel struct {
+	Emoji string `yaml:"emoji"`
+	Label string `yaml:"label"`
+}
+
+// fileConfig is the layout of the optional YAML config file
+type fileConfig struct {
+	BlockSeverity    string                `yaml:"block_severity"`
+	StatusStates     map[string]string     `yaml:"status_states"`
+	DisabledPatterns []string              `yaml:"disabled_patterns"`
+	SeverityRules    []SeverityRule        `yaml:"severity_rules"`
+	Projects         []Project             `yaml:"projects"`
+	Repos            map[string]RepoConfig `yaml:"repos"`
+	Slack            struct {
+		DisableEmoji   *bool                    `yaml:"disable_emoji"`
+		ActionText     string                   `yaml:"action_text"`
+		SeverityLabels map[string]SeverityLabel `yaml:"severity_labels"`
+		Mentions       map[string]string        `yaml:"mentions"`
+		Channels       map[string]string        `yaml:"channels"`
+	} `yaml:"slack"`
+}
+
+// SeverityRule raises (positive Adjust) or lowers (negative Adjust) the
+// severity of findings under Path by that many levels
+type SeverityRule struct {
+	Path   string `yaml:"path"`
+	Adjust int    `yaml:"adjust"`
+}
+
+// Project is a monorepo sub-project. A path ending in "/" matches
+// everything under that directory; other paths are globs matched against
+// the full path and the base name.
+type Project struct {
+	Name          string   `yaml:"name"`
+	Paths         []string `yaml:"paths"`
+	SlackChannel  string   `yaml:"slack_channel"`
+	BlockSeverity string   `yaml:"block_severity"`
+}
+
+// Matches reports whether filename belongs to the project
+func (p Project) Matches(filename string) bool {
+	return pathmatch.Any(p.Paths, filename)
+}
+
+// RepoConfig overrides the global settings for one repository. Empty
+// fields keep the global value; the lists add to it.
+type RepoConfig struct {
+	BlockSeverity string `yaml:"block_severity"`
+	SlackChannel  string `yaml:"slack_channel"`
+	// DisableAIReview turns AI review off for the repository when true,
+	// or on when false, whatever DISABLE_AI_REVIEW says
+	DisableAIReview *bool `yaml:"disable_ai_review"`
+	// RequireBlockApproval turns on RequireBlockApproval for the
+	// repository
+	RequireBlockApproval bool `yaml:"require_block_approval"`
+	// DisabledPatterns are not reported for the repository, and findings
+	// under IgnorePaths are dropped. IgnorePaths are matched like
+	// project paths.
+	DisabledPatterns []string `yaml:"disabled_patterns"`
+	IgnorePaths      []string `yaml:"ignore_paths"`
+}
+
+// Ignores reports whether findings in filename are dropped for the
+// repository
+func (r RepoConfig) Ignores(filename string) bool {
+	return pathmatch.Any(r.IgnorePaths, filename)
+}
+
+// RepoSettings returns the overrides for repo ("owner/name"), which are
+// empty when it has none
+func (c *Config) RepoSettings(repo string) RepoConfig {
+	return c.Repos[strings.ToLower(repo)]
+}
+
+// ForRepo returns the effective configuration for repo ("owner/name"),
+// or c itself when the repository has no overrides
+func (c *Config) ForRepo(repo string) *Config {
+	settings, ok := c.Repos[strings.ToLower(repo)]
+	if !ok {
+		return c
+	}
+
+	next := *c
+	if settings.BlockSeverity != "" {
+		next.BlockSeverity = settings.BlockSeverity
+	}
+	if settings.DisableAIReview != nil {
+		next.DisableAIReview = *settings.DisableAIReview
+	}
+	if settings.RequireBlockApproval {
+		next.RequireBlockApproval = true
+	}
+	if len(settings.DisabledPatterns) > 0 {
+		next.DisabledPatterns = append(append([]string(nil), c.DisabledPatterns...), settings.DisabledPatterns...)
+	}
+	return &next
+}
+
+// AIReviewUsed reports whether any repository gets AI reviews: either AI
+// review is on globally or a repository turns it back on
+func (c *Config) AIReviewUsed() bool {
+	if !c.DisableAIReview {
+		return true
+	}
+	for _, settings := range c.Repos {
+		if settings.DisableAIReview != nil && !*settings.DisableAIReview {
+			return true
+		}
+	}
+	return false
+}
+
+// ProjectFor returns the first project filename belongs to, or nil
+func (c *Config) ProjectFor(filename string) *Project {
+	for i := range c.Projects {
+		if c.Projects[i].Matches(filename) {
+			return &c.Projects[i]
+		}
+	}
+	return nil
+}
+
+// validSeverities lists the severities accepted by BLOCK_SEVERITY
+var validSeverities = map[string]bool{
+	"CRITICAL": true,
+	"HIGH":     true,
+	"MEDIUM":   true,
+	"LOW":      true,
+}
+
 func Load() (*Config, error) {
 	cfg := &Config{
 		GitHubToken:   os.Getenv("GITHUB_TOKEN"),
+		GitHubBaseURL: os.Getenv("GITHUB_BASE_URL"),
 		WebhookSecret: os.Getenv("WEBHOOK_SECRET"),
-		SlackToken:    os.Getenv("SLACK_TOKEN"),
-		SlackChannel:  os.Getenv("SLACK_CHANNEL"),
-		GeminiAPIKey:  os.Getenv("GEMINI_API_KEY"),  // CHANGED
-		Environment:   getEnvOrDefault("ENVIRONMENT", "development"),
-		Port:          getEnvOrDefault("PORT", "8080"),
-		LogLevel:      getEnvOrDefault("LOG_LEVEL", "info"),
+
+		WebhookCheckUserAgent: getEnvBool("WEBHOOK_CHECK_USER_AGENT", false),
+		WebhookMaxBytes:       getEnvInt("WEBHOOK_MAX_BYTES", 5<<20),
+		SlackToken:            os.Getenv("SLACK_TOKEN"),
+		SlackChannel:          os.Getenv("SLACK_CHANNEL"),
+		AIProvider:            strings.ToLower(getEnvOrDefault("AI_PROVIDER", "gemini")),
+		GeminiAPIKey:          os.Getenv("GEMINI_API_KEY"), // CHANGED
+		OpenAIAPIKey:          os.Getenv("OPENAI_API_KEY"),
+		OpenAIModel:           os.Getenv("OPENAI_MODEL"),
+		DisableAIReview:       getEnvBool("DISABLE_AI_REVIEW", false),
+
+		ReviewDepth:           strings.ToLower(getEnvOrDefault("REVIEW_DEPTH", "standard")),
+		ReviewCacheTTL:        getEnvDuration("REVIEW_CACHE_TTL", 24*time.Hour),
+		ReviewMaxPatchChars:   getEnvInt("REVIEW_MAX_PATCH_CHARS", 0),
+		ReviewMaxPatchLines:   getEnvInt("REVIEW_MAX_PATCH_LINES", 0),
+		ReviewPriority:        strings.ToLower(os.Getenv("REVIEW_PRIORITY")),
+		ReviewPriorityPaths:   getEnvList("REVIEW_PRIORITY_PATHS", nil),
+		ReviewMaxFiles:        getEnvInt("REVIEW_MAX_FILES", 0),
+		ReviewSkipLines:       getEnvInt("REVIEW_SKIP_LINES", 0),
+		MinReviewChanges:      getEnvInt("MIN_REVIEW_CHANGES", 0),
+		MinReviewNotify:       getEnvBool("MIN_REVIEW_NOTIFY", true),
+		ReviewSkipAuthors:     getEnvList("REVIEW_SKIP_AUTHORS", nil),
+		ReviewOnlyAuthors:     getEnvList("REVIEW_ONLY_AUTHORS", nil),
+		ReviewLanguages:       getEnvList("REVIEW_LANGUAGES", nil),
+		ReviewSkipTrivial:     getEnvBool("REVIEW_SKIP_TRIVIAL", true),
+		ReviewAddedLinesOnly:  getEnvBool("REVIEW_ADDED_LINES_ONLY", true),
+		AIRequestsPerMinute:   getEnvInt("AI_REQUESTS_PER_MINUTE", 30),
+		AIQuotaCooldown:       getEnvDuration("AI_QUOTA_COOLDOWN", 15*time.Minute),
+		AIBreakerFailures:     getEnvInt("AI_BREAKER_FAILURES", 3),
+		AIBreakerCooldown:     getEnvDuration("AI_BREAKER_COOLDOWN", 5*time.Minute),
+		AIStatus:              getEnvBool("AI_REVIEW_STATUS", false),
+		AIFailurePercent:      getEnvInt("AI_FAILURE_PERCENT", 50),
+		AIReviewGate:          getEnvBool("AI_REVIEW_GATE", false),
+		VerdictStatus:         getEnvBool("VERDICT_STATUS", false),
+		AIInlineComments:      getEnvBool("AI_INLINE_COMMENTS", false),
+		PatternsFile:          os.Getenv("PATTERNS_FILE"),
+		BaselineFile:          os.Getenv("BASELINE_FILE"),
+		ScanConcurrency:       getEnvInt("SCAN_CONCURRENCY", 4),
+		SeverityRules:         severityRulesFromEnv(),
+		ScanRenamedFiles:      getEnvBool("SCAN_RENAMED_FILES", false),
+		SkipGeneratedFiles:    getEnvBool("SKIP_GENERATED_FILES", false),
+		GeneratedDowngrade:    getEnvInt("GENERATED_SEVERITY_DOWNGRADE", 0),
+		GeneratedFilesTTL:     getEnvDuration("GENERATED_FILES_TTL", 10*time.Minute),
+		SensitiveFileSeverity: strings.ToUpper(getEnvOrDefault("SENSITIVE_FILE_SEVERITY", "HIGH")),
+		ScanBase64:            getEnvBool("SCAN_BASE64", false),
+		ScanSkipExtensions:    skipExtensionsFromEnv(),
+
+		DisabledPatterns: getEnvList("DISABLED_PATTERNS", nil),
+
+		VerifySecrets:           getEnvBool("VERIFY_SECRETS", false),
+		VerifyRequestsPerMinute: getEnvInt("VERIFY_REQUESTS_PER_MINUTE", 30),
+
+		PushBranches: getEnvList("SCAN_PUSH_BRANCHES", []string{"main", "master"}),
+
+		PushIssues:             getEnvBool("PUSH_ISSUES", true),
+		PushIssueLabels:        getEnvList("PUSH_ISSUE_LABELS", []string{"security", "gitreviewed"}),
+		ConfigFile:             os.Getenv("CONFIG_FILE"),
+		BlockSeverity:          strings.ToUpper(getEnvOrDefault("BLOCK_SEVERITY", "CRITICAL")),
+		StatusStates:           statusStatesFromEnv(),
+		RequireBlockApproval:   getEnvBool("REQUIRE_BLOCK_APPROVAL", false),
+		BlockApprovers:         getEnvList("BLOCK_APPROVERS", nil),
+		ApprovalStateFile:      os.Getenv("APPROVAL_STATE_FILE"),
+		AdminToken:             os.Getenv("ADMIN_TOKEN"),
+		AuditLogFile:           os.Getenv("AUDIT_LOG_FILE"),
+		FindingsFile:           os.Getenv("FINDINGS_FILE"),
+		FindingsFileMaxMB:      getEnvInt("FINDINGS_FILE_MAX_MB", 100),
+		FindingsFileBackups:    getEnvInt("FINDINGS_FILE_BACKUPS", 3),
+		SyncMode:               getEnvBool("SYNC_MODE", false),
+		SyncTimeout:            getEnvDuration("SYNC_TIMEOUT", 2*time.Minute),
+		PRDebounce:             getEnvDuration("PR_DEBOUNCE", 0),
+		ScanDrafts:             getEnvBool("SCAN_DRAFTS", true),
+		AuthorHistory:          getEnvBool("AUTHOR_HISTORY", true),
+		AuthorHistoryRetention: getEnvDuration("AUTHOR_HISTORY_RETENTION", 90*24*time.Hour),
+		HistorySize:            getEnvInt("HISTORY_SIZE", 100),
+
+		HTTPTimeout: getEnvDuration("HTTP_TIMEOUT", 30*time.Second),
+		AITimeout:   getEnvDuration("AI_TIMEOUT", 2*time.Minute),
+
+		OutboundProxy:   os.Getenv("OUTBOUND_PROXY"),
+		OutboundNoProxy: getEnvList("OUTBOUND_NO_PROXY", nil),
+
+		StartupScan:            getEnvBool("STARTUP_SCAN", false),
+		StartupScanRepos:       getEnvList("STARTUP_SCAN_REPOS", nil),
+		StartupScanConcurrency: getEnvInt("STARTUP_SCAN_CONCURRENCY", 2),
+		GitHubAccessCheck:      getEnvBool("GITHUB_ACCESS_CHECK", true),
+		GitHubAccessCheckRepos: getEnvList("GITHUB_ACCESS_CHECK_REPOS", getEnvList("STARTUP_SCAN_REPOS", nil)),
+		OrgPolicyFile:          os.Getenv("ORG_POLICY_FILE"),
+		OrgPolicyRepo:          os.Getenv("ORG_POLICY_REPO"),
+		OrgPolicyPath:          getEnvOrDefault("ORG_POLICY_PATH", "gitreviewed-policy.yml"),
+		OrgPolicyTTL:           getEnvDuration("ORG_POLICY_TTL", 10*time.Minute),
+
+		PostMergeCommitStatus:   getEnvBool("POST_MERGE_COMMIT_STATUS", false),
+		StatusTargetURL:         os.Getenv("STATUS_TARGET_URL"),
+		PRComment:               getEnvBool("PR_COMMENT", false),
+		PRReview:                getEnvBool("PR_REVIEW", false),
+		PRReviewCleanEvent:      strings.ToLower(getEnvOrDefault("PR_REVIEW_CLEAN_EVENT", "comment")),
+		SlackDisableEmoji:       getEnvBool("SLACK_DISABLE_EMOJI", false),
+		SlackActionText:         os.Getenv("SLACK_ACTION_TEXT"),
+		SlackMaxIssues:          getEnvInt("SLACK_MAX_ISSUES_PER_SEVERITY", 5),
+		SlackMentions:           slackMentionsFromEnv(),
+		SlackMaxBlocks:          getEnvInt("SLACK_MAX_BLOCKS", 50),
+		Digest:                  getEnvBool("DIGEST", false),
+		DigestTime:              getEnvOrDefault("DIGEST_TIME", "09:00"),
+		DigestImmediateSeverity: strings.ToUpper(getEnvOrDefault("DIGEST_IMMEDIATE_SEVERITY", "CRITICAL")),
+		DigestStateFile:         os.Getenv("DIGEST_STATE_FILE"),
+		NotifyOnClean:           getEnvBool("NOTIFY_ON_CLEAN", true),
+		SlackMaxReviewChars:     getEnvInt("SLACK_MAX_REVIEW_CHARS", 3000),
+		SlackUploadFullReview:   getEnvBool("SLACK_UPLOAD_FULL_REVIEW", false),
+		SlackChannels:           slackChannelsFromEnv(),
+		Environment:             getEnvOrDefault("ENVIRONMENT", "development"),
+		Port:                    getEnvOrDefault("PORT", "8080"),
+		LogLevel:                getEnvOrDefault("LOG_LEVEL", "info"),
+	}
+
+	if path := os.Getenv("REVIEW_PROMPT_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read REVIEW_PROMPT_FILE: %w", err)
+		}
+		cfg.ReviewPromptTemplate = string(data)
+	} else {
+		cfg.ReviewPromptTemplate = os.Getenv("REVIEW_PROMPT_TEMPLATE")
+	}
+
+	if err := cfg.resolveSecrets(); err != nil {
+		return nil, err
+	}
+
+	if err := cfg.loadFile(); err != nil {
+		return nil, err
 	}
 
 	if err := cfg.Validate(); err != nil {
@@ -43,10 +624,223 @@ func Load() (*Config, error) {
 	return cfg, nil
 }
 
+// Reload returns a copy of the configuration with the mutable settings
+// re-read from ConfigFile. The receiver is left unchanged.
+func (c *Config) Reload() (*Config, error) {
+	next := *c
+	if err := next.loadFile(); err != nil {
+		return nil, err
+	}
+	if err := next.Validate(); err != nil {
+		return nil, err
+	}
+	return &next, nil
+}
+
+// loadFile overlays settings from ConfigFile, if one is configured
+func (c *Config) loadFile() error {
+	if c.ConfigFile == "" {
+		return nil
+	}
+
+	data, err := os.ReadFile(c.ConfigFile)
+	if err != nil {
+		return fmt.Errorf("failed to read CONFIG_FILE: %w", err)
+	}
+
+	var fc fileConfig
+	if err := yaml.Unmarshal(data, &fc); err != nil {
+		return fmt.Errorf("failed to parse CONFIG_FILE: %w", err)
+	}
+
+	if fc.BlockSeverity != "" {
+		c.BlockSeverity = strings.ToUpper(fc.BlockSeverity)
+	}
+	if len(fc.StatusStates) > 0 {
+		c.StatusStates = make(map[string]string, len(fc.StatusStates))
+		for severity, state := range fc.StatusStates {
+			c.StatusStates[strings.ToUpper(severity)] = strings.ToLower(state)
+		}
+	}
+	if fc.Slack.DisableEmoji != nil {
+		c.SlackDisableEmoji = *fc.Slack.DisableEmoji
+	}
+	if fc.Slack.ActionText != "" {
+		c.SlackActionText = fc.Slack.ActionText
+	}
+	if fc.Slack.SeverityLabels != nil {
+		c.SlackSeverityLabels = make(map[string]SeverityLabel, len(fc.Slack.SeverityLabels))
+		for severity, label := range fc.Slack.SeverityLabels {
+			c.SlackSeverityLabels[strings.ToUpper(severity)] = label
+		}
+	}
+	if fc.Slack.Mentions != nil {
+		c.SlackMentions = make(map[string]string, len(fc.Slack.Mentions))
+		for severity, mention := range fc.Slack.Mentions {
+			c.SlackMentions[strings.ToUpper(severity)] = strings.TrimSpace(mention)
+		}
+	}
+	if fc.Slack.Channels != nil {
+		// Copy rather than modify the map, which is shared with the
+		// config being replaced on reload
+		channels := make(map[string]string, len(c.SlackChannels)+len(fc.Slack.Channels))
+		for route, channel := range c.SlackChannels {
+			channels[route] = channel
+		}
+		for route, channel := range fc.Slack.Channels {
+			channels[strings.ToLower(route)] = channel
+		}
+		c.SlackChannels = channels
+	}
+	if fc.DisabledPatterns != nil {
+		c.DisabledPatterns = fc.DisabledPatterns
+	}
+	if fc.SeverityRules != nil {
+		c.SeverityRules = fc.SeverityRules
+	}
+	if fc.Projects != nil {
+		c.Projects = fc.Projects
+		for i := range c.Projects {
+			c.Projects[i].BlockSeverity = strings.ToUpper(c.Projects[i].BlockSeverity)
+		}
+	}
+	if fc.Repos != nil {
+		c.Repos = make(map[string]RepoConfig, len(fc.Repos))
+		for repo, settings := range fc.Repos {
+			settings.BlockSeverity = strings.ToUpper(settings.BlockSeverity)
+			c.Repos[strings.ToLower(repo)] = settings
+		}
+	}
+
+	return nil
+}
+
+// statusStatesFromEnv reads STATUS_STATES, a comma-separated list of
+// SEVERITY=state pairs such as "HIGH=failure,MEDIUM=pending"
+func statusStatesFromEnv() map[string]string {
+	entries := getEnvList("STATUS_STATES", nil)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	states := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		severity, state, _ := strings.Cut(entry, "=")
+		states[strings.ToUpper(strings.TrimSpace(severity))] = strings.ToLower(strings.TrimSpace(state))
+	}
+	return states
+}
+
+// slackMentionsFromEnv reads SLACK_MENTIONS, a comma-separated list of
+// SEVERITY=mention pairs such as "CRITICAL=S0123ABCD,HIGH=@here"
+func slackMentionsFromEnv() map[string]string {
+	entries := getEnvList("SLACK_MENTIONS", nil)
+	if len(entries) == 0 {
+		return nil
+	}
+
+	mentions := make(map[string]string, len(entries))
+	for _, entry := range entries {
+		severity, mention, _ := strings.Cut(entry, "=")
+		mentions[strings.ToUpper(strings.TrimSpace(severity))] = strings.TrimSpace(mention)
+	}
+	return mentions
+}
+
+// SlackMention converts a configured mention to Slack's markup, reporting
+// false for values that aren't a known mention form
+func SlackMention(value string) (string, bool) {
+	switch strings.ToLower(strings.TrimPrefix(value, "@")) {
+	case "here":
+		return "<!here>", true
+	case "channel":
+		return "<!channel>", true
+	}
+	if !slackIDPattern.MatchString(value) {
+		return "", false
+	}
+	if value[0] == 'S' {
+		return "<!subteam^" + value + ">", true
+	}
+	return "<@" + value + ">", true
+}
+
+// slackIDPattern matches Slack user group and user IDs
+var slackIDPattern = regexp.MustCompile(`^[SUW][A-Z0-9]{6,}$`)
+
+// statusTargetPlaceholder matches a {name} placeholder in STATUS_TARGET_URL
+var statusTargetPlaceholder = regexp.MustCompile(`\{(\w+)\}`)
+
+// ExpandStatusTargetURL fills the {name} placeholders of a status target
+// URL template from values; unknown placeholders are left as they are
+func ExpandStatusTargetURL(template string, values map[string]string) string {
+	return statusTargetPlaceholder.ReplaceAllStringFunc(template, func(placeholder string) string {
+		if value, ok := values[placeholder[1:len(placeholder)-1]]; ok {
+			return value
+		}
+		return placeholder
+	})
+}
+
+// validateStatusTargetURL checks that a status target URL template only
+// uses known placeholders and expands to an absolute http(s) URL
+func validateStatusTargetURL(template string) error {
+	sample := map[string]string{"owner": "owner", "repo": "repo", "number": "1", "sha": "0000000"}
+	for _, match := range statusTargetPlaceholder.FindAllStringSubmatch(template, -1) {
+		if _, ok := sample[match[1]]; !ok {
+			return fmt.Errorf("unknown placeholder %s (expected {owner}, {repo}, {number} or {sha})", match[0])
+		}
+	}
+	u, err := url.Parse(ExpandStatusTargetURL(template, sample))
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("expected an absolute http or https URL")
+	}
+	return nil
+}
+
+// severityRulesFromEnv builds one-level severity rules from
+// SEVERITY_DOWNGRADE_PATHS and SEVERITY_UPGRADE_PATHS; downgrades are
+// checked first
+func severityRulesFromEnv() []SeverityRule {
+	var rules []SeverityRule
+	for _, p := range getEnvList("SEVERITY_DOWNGRADE_PATHS", nil) {
+		rules = append(rules, SeverityRule{Path: p, Adjust: -1})
+	}
+	for _, p := range getEnvList("SEVERITY_UPGRADE_PATHS", nil) {
+		rules = append(rules, SeverityRule{Path: p, Adjust: 1})
+	}
+	return rules
+}
+
+// slackChannelsFromEnv reads the channel routes that can be set from the
+// environment; CONFIG_FILE can add or override any route
+func slackChannelsFromEnv() map[string]string {
+	channels := make(map[string]string)
+	if channel := os.Getenv("SLACK_SECURITY_CHANNEL"); channel != "" {
+		channels["security_critical"] = channel
+	}
+	if channel := os.Getenv("SLACK_REVIEW_CHANNEL"); channel != "" {
+		channels["ai_review"] = channel
+	}
+	if channel := os.Getenv("OPS_SLACK_CHANNEL"); channel != "" {
+		channels["ops"] = channel
+	}
+	if channel := os.Getenv("DIGEST_SLACK_CHANNEL"); channel != "" {
+		channels["digest"] = channel
+	}
+	return channels
+}
+
 func (c *Config) Validate() error {
 	if c.GitHubToken == "" {
 		return fmt.Errorf("GITHUB_TOKEN is required")
 	}
+	if c.GitHubBaseURL != "" {
+		u, err := url.Parse(c.GitHubBaseURL)
+		if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			return fmt.Errorf("invalid GITHUB_BASE_URL %q (expected e.g. https://ghe.company.com/api/v3)", c.GitHubBaseURL)
+		}
+	}
 	if c.WebhookSecret == "" {
 		return fmt.Errorf("WEBHOOK_SECRET is required")
 	}
@@ -56,8 +850,211 @@ func (c *Config) Validate() error {
 	if c.SlackChannel == "" {
 		return fmt.Errorf("SLACK_CHANNEL is required")
 	}
-	if c.GeminiAPIKey == "" {
-		return fmt.Errorf("GEMINI_API_KEY is required")
+	switch {
+	case !c.AIReviewUsed():
+		if c.AIReviewGate {
+			return fmt.Errorf("AI_REVIEW_GATE requires AI review, but DISABLE_AI_REVIEW is set")
+		}
+	case c.AIProvider == "gemini":
+		if c.GeminiAPIKey == "" {
+			return fmt.Errorf("GEMINI_API_KEY is required")
+		}
+	case c.AIProvider == "openai":
+		if c.OpenAIAPIKey == "" {
+			return fmt.Errorf("OPENAI_API_KEY is required when AI_PROVIDER is openai")
+		}
+	default:
+		return fmt.Errorf("invalid AI_PROVIDER %q (expected gemini or openai)", c.AIProvider)
+	}
+	switch c.ReviewDepth {
+	case "brief", "standard", "thorough":
+	default:
+		return fmt.Errorf("invalid REVIEW_DEPTH %q (expected brief, standard or thorough)", c.ReviewDepth)
+	}
+	if c.ReviewMaxPatchChars < 0 || c.ReviewMaxPatchLines < 0 {
+		return fmt.Errorf("REVIEW_MAX_PATCH_CHARS and REVIEW_MAX_PATCH_LINES must not be negative")
+	}
+	switch c.ReviewPriority {
+	case "", "additions", "path", "alpha":
+	default:
+		return fmt.Errorf("invalid REVIEW_PRIORITY %q (expected additions, path or alpha)", c.ReviewPriority)
+	}
+	if c.ReviewPriority == "path" && len(c.ReviewPriorityPaths) == 0 {
+		return fmt.Errorf("REVIEW_PRIORITY_PATHS is required when REVIEW_PRIORITY is path")
+	}
+	if c.AIFailurePercent < 1 || c.AIFailurePercent > 100 {
+		return fmt.Errorf("AI_FAILURE_PERCENT must be between 1 and 100")
+	}
+	if c.AIBreakerFailures < 0 {
+		return fmt.Errorf("AI_BREAKER_FAILURES must not be negative")
+	}
+	if c.AIBreakerFailures > 0 && c.AIBreakerCooldown <= 0 {
+		return fmt.Errorf("AI_BREAKER_COOLDOWN must be positive")
+	}
+	if c.SlackMaxBlocks < 10 || c.SlackMaxBlocks > 50 {
+		return fmt.Errorf("SLACK_MAX_BLOCKS must be between 10 and 50")
+	}
+	if c.Digest {
+		if _, err := time.Parse("15:04", c.DigestTime); err != nil {
+			return fmt.Errorf("invalid DIGEST_TIME %q (expected HH:MM)", c.DigestTime)
+		}
+		if !validSeverities[c.DigestImmediateSeverity] {
+			return fmt.Errorf("invalid DIGEST_IMMEDIATE_SEVERITY %q", c.DigestImmediateSeverity)
+		}
+	}
+	if c.HistorySize < 0 {
+		return fmt.Errorf("HISTORY_SIZE must not be negative")
+	}
+	if c.StatusTargetURL != "" {
+		if err := validateStatusTargetURL(c.StatusTargetURL); err != nil {
+			return fmt.Errorf("invalid STATUS_TARGET_URL: %w", err)
+		}
+	}
+	if c.PRDebounce < 0 {
+		return fmt.Errorf("PR_DEBOUNCE must not be negative")
+	}
+	switch c.PRReviewCleanEvent {
+	case "approve", "comment", "none":
+	default:
+		return fmt.Errorf("invalid PR_REVIEW_CLEAN_EVENT %q (expected approve, comment or none)", c.PRReviewCleanEvent)
+	}
+	if c.ScanConcurrency < 1 {
+		return fmt.Errorf("SCAN_CONCURRENCY must be at least 1")
+	}
+	for _, ext := range c.ScanSkipExtensions {
+		if strings.ContainsAny(ext, "/*?[") {
+			return fmt.Errorf("invalid SCAN_SKIP_EXTENSIONS entry %q (expected an extension such as .png)", ext)
+		}
+	}
+	if c.SensitiveFileSeverity != "OFF" && !validSeverities[c.SensitiveFileSeverity] {
+		return fmt.Errorf("invalid SENSITIVE_FILE_SEVERITY %q (expected a severity or off)", c.SensitiveFileSeverity)
+	}
+	if c.MinReviewChanges < 0 {
+		return fmt.Errorf("MIN_REVIEW_CHANGES must not be negative")
+	}
+	if c.ReviewSkipLines > 0 && c.MinReviewChanges > c.ReviewSkipLines {
+		return fmt.Errorf("MIN_REVIEW_CHANGES can't exceed REVIEW_SKIP_LINES")
+	}
+	if c.GeneratedDowngrade < 0 || c.GeneratedDowngrade > 3 {
+		return fmt.Errorf("GENERATED_SEVERITY_DOWNGRADE must be between 0 and 3")
+	}
+	if c.GeneratedFilesTTL <= 0 {
+		return fmt.Errorf("GENERATED_FILES_TTL must be positive")
+	}
+	for _, rule := range c.SeverityRules {
+		if _, err := path.Match(rule.Path, ""); err != nil || rule.Path == "" {
+			return fmt.Errorf("invalid severity rule path %q", rule.Path)
+		}
+		if rule.Adjust == 0 || rule.Adjust < -3 || rule.Adjust > 3 {
+			return fmt.Errorf("severity rule for %q must adjust by 1 to 3 levels up or down", rule.Path)
+		}
+	}
+	if !validSeverities[c.BlockSeverity] {
+		return fmt.Errorf("invalid BLOCK_SEVERITY %q", c.BlockSeverity)
+	}
+	projects := make(map[string]bool, len(c.Projects))
+	for _, project := range c.Projects {
+		if project.Name == "" || projects[project.Name] {
+			return fmt.Errorf("every project needs a unique name, got %q", project.Name)
+		}
+		projects[project.Name] = true
+		if len(project.Paths) == 0 {
+			return fmt.Errorf("project %q has no paths", project.Name)
+		}
+		for _, p := range project.Paths {
+			if _, err := path.Match(p, ""); err != nil || p == "" {
+				return fmt.Errorf("invalid path %q in project %q", p, project.Name)
+			}
+		}
+		if project.BlockSeverity != "" && !validSeverities[project.BlockSeverity] {
+			return fmt.Errorf("invalid block_severity %q in project %q", project.BlockSeverity, project.Name)
+		}
+	}
+	if c.RequireBlockApproval && len(c.BlockApprovers) == 0 && c.AdminToken == "" {
+		return fmt.Errorf("REQUIRE_BLOCK_APPROVAL needs BLOCK_APPROVERS or ADMIN_TOKEN, or blocks could never be cleared")
+	}
+	for repo, settings := range c.Repos {
+		if settings.RequireBlockApproval && len(c.BlockApprovers) == 0 && c.AdminToken == "" {
+			return fmt.Errorf("require_block_approval for %s needs BLOCK_APPROVERS or ADMIN_TOKEN", repo)
+		}
+		if owner, name, ok := strings.Cut(repo, "/"); !ok || owner == "" || name == "" {
+			return fmt.Errorf("invalid repository %q in repos (expected owner/name)", repo)
+		}
+		if settings.BlockSeverity != "" && !validSeverities[settings.BlockSeverity] {
+			return fmt.Errorf("invalid block_severity %q for %s", settings.BlockSeverity, repo)
+		}
+		for _, p := range settings.IgnorePaths {
+			if _, err := path.Match(p, ""); err != nil || p == "" {
+				return fmt.Errorf("invalid ignore path %q for %s", p, repo)
+			}
+		}
+	}
+	for severity, mention := range c.SlackMentions {
+		if !validSeverities[severity] {
+			return fmt.Errorf("invalid SLACK_MENTIONS severity %q", severity)
+		}
+		if _, ok := SlackMention(mention); !ok {
+			return fmt.Errorf("invalid SLACK_MENTIONS mention %q for %s (expected @here, @channel, a user group ID or a user ID)", mention, severity)
+		}
+	}
+	for severity, state := range c.StatusStates {
+		if !validSeverities[severity] {
+			return fmt.Errorf("invalid STATUS_STATES severity %q", severity)
+		}
+		switch state {
+		case "failure", "pending", "success":
+		default:
+			return fmt.Errorf("invalid STATUS_STATES state %q for %s (expected failure, pending or success)", state, severity)
+		}
+	}
+	if c.FindingsFileMaxMB < 1 || c.FindingsFileBackups < 0 {
+		return fmt.Errorf("FINDINGS_FILE_MAX_MB must be at least 1 and FINDINGS_FILE_BACKUPS not negative")
+	}
+	if c.WebhookMaxBytes <= 0 {
+		return fmt.Errorf("WEBHOOK_MAX_BYTES must be positive")
+	}
+	if c.HTTPTimeout <= 0 || c.AITimeout <= 0 {
+		return fmt.Errorf("HTTP_TIMEOUT and AI_TIMEOUT must be positive")
+	}
+	if c.OutboundProxy != "" {
+		u, err := url.Parse(c.OutboundProxy)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https" && u.Scheme != "socks5") || u.Host == "" {
+			return fmt.Errorf("invalid OUTBOUND_PROXY %q (expected e.g. http://proxy.company.com:3128)", c.OutboundProxy)
+		}
+	}
+	if c.VerifySecrets && c.VerifyRequestsPerMinute < 1 {
+		return fmt.Errorf("VERIFY_REQUESTS_PER_MINUTE must be at least 1 when VERIFY_SECRETS is enabled")
+	}
+	for _, repo := range c.GitHubAccessCheckRepos {
+		if parts := strings.Split(repo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid GITHUB_ACCESS_CHECK_REPOS entry %q (expected owner/name)", repo)
+		}
+	}
+	if c.StartupScan {
+		if len(c.StartupScanRepos) == 0 {
+			return fmt.Errorf("STARTUP_SCAN_REPOS is required when STARTUP_SCAN is enabled")
+		}
+		for _, repo := range c.StartupScanRepos {
+			if parts := strings.Split(repo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+				return fmt.Errorf("invalid STARTUP_SCAN_REPOS entry %q (expected owner/name)", repo)
+			}
+		}
+		if c.StartupScanConcurrency < 1 {
+			return fmt.Errorf("STARTUP_SCAN_CONCURRENCY must be at least 1")
+		}
+	}
+	if c.OrgPolicyRepo != "" {
+		if c.OrgPolicyFile != "" {
+			return fmt.Errorf("ORG_POLICY_FILE and ORG_POLICY_REPO are mutually exclusive")
+		}
+		if parts := strings.Split(c.OrgPolicyRepo, "/"); len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+			return fmt.Errorf("invalid ORG_POLICY_REPO %q (expected owner/name)", c.OrgPolicyRepo)
+		}
+	}
+	if c.ReviewPromptTemplate != "" {
+		if _, err := ai.ParsePromptTemplate(c.ReviewPromptTemplate); err != nil {
+			return fmt.Errorf("invalid review prompt template: %w", err)
+		}
 	}
 	return nil
 }
@@ -78,4 +1075,59 @@ func getEnvOrDefault(key, defaultValue string) string {
 		return value
 	}
 	return defaultValue
-}
\ No newline at end of file
+}
+
+// getEnvBool gets a boolean environment variable or returns a default value
+func getEnvBool(key string, defaultValue bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvInt gets an integer environment variable or returns a default value
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvDuration gets a duration environment variable (e.g. "30s", "24h")
+// or returns a default value
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value, err := time.ParseDuration(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// skipExtensionsFromEnv reads SCAN_SKIP_EXTENSIONS, where "none" turns
+// the skip-list off
+func skipExtensionsFromEnv() []string {
+	list := getEnvList("SCAN_SKIP_EXTENSIONS", nil)
+	if len(list) == 1 && strings.EqualFold(list[0], "none") {
+		return []string{}
+	}
+	return list
+}
+
+// getEnvList gets a comma-separated environment variable as a list or
+// returns a default value
+func getEnvList(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
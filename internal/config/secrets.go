@@ -0,0 +1,99 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/transport"
+)
+
+// ErrSecretNotFound is returned by a SecretSource that has no value for a
+// secret
+var ErrSecretNotFound = errors.New("secret not found")
+
+// secretLoadTimeout bounds resolving all secrets at startup
+const secretLoadTimeout = 30 * time.Second
+
+// SecretSource resolves sensitive settings such as API tokens by name
+type SecretSource interface {
+	Get(ctx context.Context, name string) (string, error)
+}
+
+// EnvSource reads secrets from environment variables. It is the default.
+type EnvSource struct{}
+
+// Get returns the environment variable name
+func (EnvSource) Get(ctx context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return value, nil
+}
+
+// newSecretSource creates the source selected by SECRETS_SOURCE. Cloud
+// sources make their calls with httpClient.
+func newSecretSource(ctx context.Context, httpClient *http.Client) (SecretSource, error) {
+	prefix := os.Getenv("SECRETS_PREFIX")
+
+	switch source := strings.ToLower(getEnvOrDefault("SECRETS_SOURCE", "env")); source {
+	case "env":
+		return EnvSource{}, nil
+	case "aws":
+		return NewAWSSecretsManagerSource(ctx, prefix, httpClient)
+	case "gcp":
+		project := os.Getenv("SECRETS_GCP_PROJECT")
+		if project == "" {
+			return nil, fmt.Errorf("SECRETS_GCP_PROJECT is required when SECRETS_SOURCE is gcp")
+		}
+		return NewGCPSecretManagerSource(ctx, project, prefix, httpClient)
+	default:
+		return nil, fmt.Errorf("invalid SECRETS_SOURCE %q (expected env, aws or gcp)", source)
+	}
+}
+
+// resolveSecrets reads the sensitive settings through the configured
+// SecretSource. A secret the source doesn't have keeps its environment
+// value. Calls go through OUTBOUND_PROXY like every other outbound call.
+func (c *Config) resolveSecrets() error {
+	ctx, cancel := context.WithTimeout(context.Background(), secretLoadTimeout)
+	defer cancel()
+
+	httpClient := &http.Client{
+		Transport: transport.New(c.OutboundProxy, c.OutboundNoProxy),
+		Timeout:   10 * time.Second,
+	}
+	source, err := newSecretSource(ctx, httpClient)
+	if err != nil {
+		return err
+	}
+	if _, ok := source.(EnvSource); ok {
+		return nil
+	}
+
+	secrets := map[string]*string{
+		"GITHUB_TOKEN":   &c.GitHubToken,
+		"WEBHOOK_SECRET": &c.WebhookSecret,
+		"SLACK_TOKEN":    &c.SlackToken,
+		"GEMINI_API_KEY": &c.GeminiAPIKey,
+		"OPENAI_API_KEY": &c.OpenAIAPIKey,
+		"ADMIN_TOKEN":    &c.AdminToken,
+	}
+	for name, field := range secrets {
+		value, err := source.Get(ctx, name)
+		if errors.Is(err, ErrSecretNotFound) {
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("failed to load secret %s: %w", name, err)
+		}
+		*field = value
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+)
+
+// AWSSecretsManagerSource reads secrets from AWS Secrets Manager. The
+// secret ID is prefix + name. Credentials and the region come from the
+// standard AWS chain: environment variables, shared config and profiles,
+// web identity (IRSA), ECS/EKS container credentials and instance
+// metadata. Credentials are cached and refreshed before they expire.
+type AWSSecretsManagerSource struct {
+	client *secretsmanager.Client
+	prefix string
+}
+
+// NewAWSSecretsManagerSource creates a Secrets Manager source whose calls,
+// including credential lookups, are made with httpClient
+func NewAWSSecretsManagerSource(ctx context.Context, prefix string, httpClient *http.Client) (*AWSSecretsManagerSource, error) {
+	cfg, err := awsconfig.LoadDefaultConfig(ctx, awsconfig.WithHTTPClient(httpClient))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load AWS configuration: %w", err)
+	}
+	if cfg.Region == "" {
+		return nil, fmt.Errorf("AWS_REGION is required when SECRETS_SOURCE is aws")
+	}
+
+	return &AWSSecretsManagerSource{
+		client: secretsmanager.NewFromConfig(cfg),
+		prefix: prefix,
+	}, nil
+}
+
+// Get reads the current value of the secret prefix + name
+func (s *AWSSecretsManagerSource) Get(ctx context.Context, name string) (string, error) {
+	out, err := s.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(s.prefix + name),
+	})
+	var notFound *types.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return "", ErrSecretNotFound
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to get secret value: %w", err)
+	}
+	if out.SecretString == nil {
+		return "", fmt.Errorf("secret %s has no string value", s.prefix+name)
+	}
+	return *out.SecretString, nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/google"
+)
+
+// gcpSecretManagerURL is the Secret Manager access endpoint for the latest
+// version of a secret
+const gcpSecretManagerURL = "https://secretmanager.googleapis.com/v1/projects/%s/secrets/%s/versions/latest:access"
+
+// GCPSecretManagerSource reads secrets from Google Cloud Secret Manager
+// using Application Default Credentials. The secret ID is prefix + name.
+type GCPSecretManagerSource struct {
+	client  *http.Client
+	project string
+	prefix  string
+}
+
+// NewGCPSecretManagerSource creates a Secret Manager source for project
+// whose calls, including token refreshes, are made with httpClient
+func NewGCPSecretManagerSource(ctx context.Context, project, prefix string, httpClient *http.Client) (*GCPSecretManagerSource, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	client, err := google.DefaultClient(ctx, "https://www.googleapis.com/auth/cloud-platform")
+	if err != nil {
+		return nil, fmt.Errorf("failed to load GCP credentials: %w", err)
+	}
+
+	return &GCPSecretManagerSource{
+		client:  client,
+		project: project,
+		prefix:  prefix,
+	}, nil
+}
+
+// Get reads the latest version of the secret prefix + name
+func (s *GCPSecretManagerSource) Get(ctx context.Context, name string) (string, error) {
+	endpoint := fmt.Sprintf(gcpSecretManagerURL, url.PathEscape(s.project), url.PathEscape(s.prefix+name))
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to access secret: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrSecretNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to access secret: status %d", resp.StatusCode)
+	}
+
+	var result struct {
+		Payload struct {
+			Data string `json:"data"`
+		} `json:"payload"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode secret: %w", err)
+	}
+
+	data, err := base64.StdEncoding.DecodeString(result.Payload.Data)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode secret payload: %w", err)
+	}
+	return string(data), nil
+}
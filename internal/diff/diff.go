@@ -0,0 +1,200 @@
+// Package diff parses the unified diff patches GitHub returns for each
+// changed file into hunks with file line numbers, so the scanner and the
+// AI review share one implementation of the fiddly parts.
+package diff
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Kind is the kind of a line in a patch
+type Kind int
+
+const (
+	// Context is an unchanged line shown around the changes
+	Context Kind = iota
+	// Added is a line only in the new file
+	Added
+	// Removed is a line only in the old file
+	Removed
+	// Header is a hunk header such as "@@ -10,4 +12,6 @@ func main()"
+	Header
+	// NoNewline is the "\ No newline at end of file" marker
+	NoNewline
+)
+
+// Line is a single line of a patch
+type Line struct {
+	Kind Kind
+	// Text is the line without its diff marker; headers keep theirs
+	Text string
+	// Index is the 1-based position of the line in the patch
+	Index int
+	// OldLine and NewLine are the line in the old and new file, 0 when
+	// the line isn't in that file
+	OldLine int
+	NewLine int
+}
+
+// Hunk is a block of changes with the context around them
+type Hunk struct {
+	OldStart, OldCount int
+	NewStart, NewCount int
+	// Section is the text after the header, often the enclosing function
+	Section string
+	// Lines are the hunk's lines, not including its header
+	Lines []Line
+}
+
+// Patch is a parsed patch. Lines before the first hunk header, such as a
+// whole file given as added lines, form a hunk starting at line 1.
+type Patch struct {
+	Hunks []Hunk
+
+	// positions maps each patch line to the new file line it sits at:
+	// its own line, or for removed lines and headers the next one
+	positions []int
+}
+
+// Parse parses a unified diff patch
+func Parse(patch string) *Patch {
+	return ParseLines(strings.Split(strings.TrimSuffix(patch, "\n"), "\n"))
+}
+
+// ParseLines parses a patch already split into lines
+func ParseLines(lines []string) *Patch {
+	p := &Patch{positions: make([]int, len(lines))}
+	var hunk *Hunk
+	oldLine, newLine := 1, 1
+
+	for i, text := range lines {
+		if header, ok := ParseHeader(text); ok {
+			p.Hunks = append(p.Hunks, header)
+			hunk = &p.Hunks[len(p.Hunks)-1]
+			oldLine, newLine = header.OldStart, header.NewStart
+			p.positions[i] = newLine
+			continue
+		}
+		if hunk == nil {
+			p.Hunks = append(p.Hunks, Hunk{OldStart: 1, NewStart: 1})
+			hunk = &p.Hunks[len(p.Hunks)-1]
+		}
+
+		line := Line{Index: i + 1, Text: text}
+		switch {
+		case strings.HasPrefix(text, "+"):
+			line.Kind, line.Text = Added, text[1:]
+			line.NewLine = newLine
+			newLine++
+		case strings.HasPrefix(text, "-"):
+			line.Kind, line.Text = Removed, text[1:]
+			line.OldLine = oldLine
+			oldLine++
+		case strings.HasPrefix(text, `\`):
+			line.Kind = NoNewline
+		default:
+			if strings.HasPrefix(text, " ") {
+				line.Text = text[1:]
+			}
+			line.OldLine, line.NewLine = oldLine, newLine
+			oldLine++
+			newLine++
+		}
+
+		p.positions[i] = newLine
+		if line.NewLine > 0 {
+			p.positions[i] = line.NewLine
+		}
+		hunk.Lines = append(hunk.Lines, line)
+	}
+
+	return p
+}
+
+// ParseHeader parses a hunk header such as "@@ -10,4 +12,6 @@ func main()".
+// Omitted counts default to 1, as in the unified diff format.
+func ParseHeader(text string) (Hunk, bool) {
+	rest, ok := strings.CutPrefix(text, "@@ -")
+	if !ok {
+		return Hunk{}, false
+	}
+	ranges, section, ok := strings.Cut(rest, " @@")
+	if !ok {
+		return Hunk{}, false
+	}
+	oldRange, newRange, ok := strings.Cut(ranges, " +")
+	if !ok {
+		return Hunk{}, false
+	}
+
+	var h Hunk
+	if h.OldStart, h.OldCount, ok = parseRange(oldRange); !ok {
+		return Hunk{}, false
+	}
+	if h.NewStart, h.NewCount, ok = parseRange(newRange); !ok {
+		return Hunk{}, false
+	}
+	h.Section = strings.TrimSpace(section)
+	return h, true
+}
+
+// parseRange parses the "start,count" of a hunk header
+func parseRange(text string) (int, int, bool) {
+	startText, countText, hasCount := strings.Cut(text, ",")
+	start, err := strconv.Atoi(startText)
+	if err != nil {
+		return 0, 0, false
+	}
+	count := 1
+	if hasCount {
+		if count, err = strconv.Atoi(countText); err != nil {
+			return 0, 0, false
+		}
+	}
+	return start, count, true
+}
+
+// Lines returns every line of the patch except hunk headers, in order
+func (p *Patch) Lines() []Line {
+	var lines []Line
+	for _, hunk := range p.Hunks {
+		lines = append(lines, hunk.Lines...)
+	}
+	return lines
+}
+
+// Added returns the added lines of the patch
+func (p *Patch) Added() []Line {
+	var added []Line
+	for _, hunk := range p.Hunks {
+		for _, line := range hunk.Lines {
+			if line.Kind == Added {
+				added = append(added, line)
+			}
+		}
+	}
+	return added
+}
+
+// NewLines returns the lines of the new file shown in the patch, added or
+// unchanged, which are the lines a review comment can be placed on
+func (p *Patch) NewLines() map[int]bool {
+	lines := make(map[int]bool)
+	for _, line := range p.Lines() {
+		if line.NewLine > 0 {
+			lines[line.NewLine] = true
+		}
+	}
+	return lines
+}
+
+// Position returns the line in the new file that the patch line at the
+// 1-based index sits at. Removed lines and hunk headers sit at the next
+// line of the new file. Out-of-range indexes are returned unchanged.
+func (p *Patch) Position(index int) int {
+	if index < 1 || index > len(p.positions) {
+		return index
+	}
+	return p.positions[index-1]
+}
@@ -0,0 +1,130 @@
+package diff
+
+import (
+	"reflect"
+	"testing"
+)
+
+// multiHunkPatch changes two places of a file: line 3 is replaced and,
+// further down, a line is added after the old line 20
+const multiHunkPatch = `@@ -1,4 +1,4 @@ package main
+ package main
+ 
+-var a = 1
++var a = 2
+ var b = 3
+@@ -20,2 +20,3 @@ func main() {
+ 	run()
++	stop()
+ }
+\ No newline at end of file`
+
+func TestParseHeader(t *testing.T) {
+	tests := []struct {
+		text string
+		want Hunk
+		ok   bool
+	}{
+		{"@@ -10,4 +12,6 @@ func main()", Hunk{OldStart: 10, OldCount: 4, NewStart: 12, NewCount: 6, Section: "func main()"}, true},
+		{"@@ -1 +1 @@", Hunk{OldStart: 1, OldCount: 1, NewStart: 1, NewCount: 1}, true},
+		{"@@ -0,0 +1 @@", Hunk{OldStart: 0, OldCount: 0, NewStart: 1, NewCount: 1}, true},
+		{"@@ -5 +5,0 @@", Hunk{OldStart: 5, OldCount: 1, NewStart: 5, NewCount: 0}, true},
+		{"@@ -a,1 +1 @@", Hunk{}, false},
+		{"@@ -1,1 +1,1", Hunk{}, false},
+		{"+@@ -1 +1 @@", Hunk{}, false},
+	}
+
+	for _, tt := range tests {
+		got, ok := ParseHeader(tt.text)
+		if ok != tt.ok || !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("ParseHeader(%q) = %+v, %v; want %+v, %v", tt.text, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestParseMultipleHunks(t *testing.T) {
+	p := Parse(multiHunkPatch)
+
+	if len(p.Hunks) != 2 {
+		t.Fatalf("got %d hunks, want 2", len(p.Hunks))
+	}
+	if h := p.Hunks[1]; h.OldStart != 20 || h.NewStart != 20 || h.NewCount != 3 || h.Section != "func main() {" {
+		t.Errorf("second hunk = %+v", h)
+	}
+
+	want := []Line{
+		{Kind: Context, Text: "package main", Index: 2, OldLine: 1, NewLine: 1},
+		{Kind: Context, Text: "", Index: 3, OldLine: 2, NewLine: 2},
+		{Kind: Removed, Text: "var a = 1", Index: 4, OldLine: 3},
+		{Kind: Added, Text: "var a = 2", Index: 5, NewLine: 3},
+		{Kind: Context, Text: "var b = 3", Index: 6, OldLine: 4, NewLine: 4},
+		{Kind: Context, Text: "\trun()", Index: 8, OldLine: 20, NewLine: 20},
+		{Kind: Added, Text: "\tstop()", Index: 9, NewLine: 21},
+		{Kind: Context, Text: "}", Index: 10, OldLine: 21, NewLine: 22},
+		{Kind: NoNewline, Text: `\ No newline at end of file`, Index: 11},
+	}
+	if got := p.Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Lines() =\n%+v\nwant\n%+v", got, want)
+	}
+}
+
+func TestAdded(t *testing.T) {
+	var got []int
+	for _, line := range Parse(multiHunkPatch).Added() {
+		got = append(got, line.NewLine)
+	}
+	if want := []int{3, 21}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Added() at lines %v, want %v", got, want)
+	}
+}
+
+func TestNewLines(t *testing.T) {
+	got := Parse(multiHunkPatch).NewLines()
+	want := map[int]bool{1: true, 2: true, 3: true, 4: true, 20: true, 21: true, 22: true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NewLines() = %v, want %v", got, want)
+	}
+}
+
+func TestPosition(t *testing.T) {
+	p := Parse(multiHunkPatch)
+
+	tests := []struct {
+		index int
+		want  int
+	}{
+		{1, 1},   // first header sits at its hunk's first line
+		{4, 3},   // removed line sits at the next new line
+		{5, 3},   // added line
+		{6, 4},   // context line
+		{7, 20},  // second header
+		{9, 21},  // added line in the second hunk
+		{11, 23}, // no-newline marker, past the last line
+		{0, 0},   // out of range
+		{12, 12}, // out of range
+	}
+	for _, tt := range tests {
+		if got := p.Position(tt.index); got != tt.want {
+			t.Errorf("Position(%d) = %d, want %d", tt.index, got, tt.want)
+		}
+	}
+}
+
+func TestParseContentBeforeFirstHeader(t *testing.T) {
+	p := Parse("+first\n+second\n@@ -10,1 +10,2 @@\n ten\n+eleven\n")
+
+	if len(p.Hunks) != 2 {
+		t.Fatalf("got %d hunks, want 2", len(p.Hunks))
+	}
+	if h := p.Hunks[0]; h.OldStart != 1 || h.NewStart != 1 {
+		t.Errorf("implicit hunk starts at -%d +%d, want -1 +1", h.OldStart, h.NewStart)
+	}
+
+	var got []int
+	for _, line := range p.Added() {
+		got = append(got, line.NewLine)
+	}
+	if want := []int{1, 2, 11}; !reflect.DeepEqual(got, want) {
+		t.Errorf("added lines at %v, want %v", got, want)
+	}
+}
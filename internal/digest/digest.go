@@ -0,0 +1,96 @@
+// Package digest collects the outstanding findings of open PRs for a
+// scheduled Slack summary
+package digest
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// Store keeps the latest findings of each open PR. Implementations must be
+// safe for concurrent use.
+type Store interface {
+	// Update replaces the findings recorded for the entry's PR; an entry
+	// without issues removes the PR
+	Update(entry models.DigestEntry)
+
+	// Remove forgets a PR, e.g. once it is closed
+	Remove(repo string, number int)
+
+	// Entries returns the PRs with findings, ordered by repository and
+	// PR number
+	Entries() []models.DigestEntry
+}
+
+// MemoryStore keeps digest entries in memory; they are lost on restart
+type MemoryStore struct {
+	mu      sync.Mutex
+	entries map[string]models.DigestEntry
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{entries: make(map[string]models.DigestEntry)}
+}
+
+// Update replaces the findings recorded for the entry's PR
+func (m *MemoryStore) Update(entry models.DigestEntry) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := prKey(entry.Repository.FullName, entry.PullRequest.Number)
+	if len(entry.Issues) == 0 {
+		delete(m.entries, key)
+		return
+	}
+	m.entries[key] = entry
+}
+
+// Remove forgets a PR
+func (m *MemoryStore) Remove(repo string, number int) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.entries, prKey(repo, number))
+}
+
+// Entries returns the PRs with findings
+func (m *MemoryStore) Entries() []models.DigestEntry {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	entries := make([]models.DigestEntry, 0, len(m.entries))
+	for _, entry := range m.entries {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Repository.FullName != entries[j].Repository.FullName {
+			return entries[i].Repository.FullName < entries[j].Repository.FullName
+		}
+		return entries[i].PullRequest.Number < entries[j].PullRequest.Number
+	})
+	return entries
+}
+
+// prKey identifies a PR as "owner/name#number"
+func prKey(repo string, number int) string {
+	return fmt.Sprintf("%s#%d", repo, number)
+}
+
+// NextRun returns the first time after now at the clock time at ("15:04")
+// in now's location
+func NextRun(now time.Time, at string) (time.Time, error) {
+	clock, err := time.Parse("15:04", at)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid digest time %q (expected HH:MM): %w", at, err)
+	}
+
+	next := time.Date(now.Year(), now.Month(), now.Day(), clock.Hour(), clock.Minute(), 0, 0, now.Location())
+	if !next.After(now) {
+		next = next.AddDate(0, 0, 1)
+	}
+	return next, nil
+}
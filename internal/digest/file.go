@@ -0,0 +1,83 @@
+package digest
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"github.com/Rishav176/GitReviewed/internal/atomicfile"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// FileStore is a MemoryStore saved to a JSON file, so findings waiting for
+// the digest survive a restart. The file is rewritten on every change;
+// findings hold only redacted matches.
+type FileStore struct {
+	*MemoryStore
+	path string
+}
+
+// NewFileStore loads the digest entries saved at path, if any
+func NewFileStore(path string) (*FileStore, error) {
+	s := &FileStore{MemoryStore: NewMemoryStore(), path: path}
+
+	data, err := atomicfile.Read(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read digest state: %w", err)
+	}
+	if data == nil {
+		return s, nil
+	}
+
+	var entries []models.DigestEntry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse digest state %s: %w", path, err)
+	}
+	for _, entry := range entries {
+		s.MemoryStore.Update(entry)
+	}
+	return s, nil
+}
+
+// Update replaces the findings recorded for the entry's PR and saves the
+// store
+func (s *FileStore) Update(entry models.DigestEntry) {
+	s.MemoryStore.Update(entry)
+	s.save()
+}
+
+// Remove forgets a PR and saves the store
+func (s *FileStore) Remove(repo string, number int) {
+	s.MemoryStore.Remove(repo, number)
+	s.save()
+}
+
+// save replaces the file with the current entries. A failed save is
+// logged; the entries stay in memory and the next change retries.
+func (s *FileStore) save() {
+	if err := s.write(); err != nil {
+		log.Printf("🚨 Failed to save digest state: %v", err)
+	}
+}
+
+// write replaces the file with the current entries
+func (s *FileStore) write() error {
+	// Entries and the rename are serialized so an older snapshot can't
+	// replace a newer one
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries := make([]models.DigestEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entries = append(entries, entry)
+	}
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return fmt.Errorf("failed to encode digest state: %w", err)
+	}
+
+	if err := atomicfile.Write(s.path, data, 0o600, nil); err != nil {
+		return fmt.Errorf("failed to save digest state: %w", err)
+	}
+	return nil
+}
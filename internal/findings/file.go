@@ -0,0 +1,118 @@
+package findings
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+)
+
+// FileLogger appends records to a file as JSON lines. Once the file would
+// grow past maxBytes it is rotated to path.1, shifting older files up to
+// path.<backups>; the oldest is removed.
+type FileLogger struct {
+	mu       sync.Mutex
+	path     string
+	maxBytes int64
+	backups  int
+	file     *os.File
+	size     int64
+}
+
+// NewFileLogger opens path for appending, creating it if needed
+func NewFileLogger(path string, maxBytes int64, backups int) (*FileLogger, error) {
+	l := &FileLogger{path: path, maxBytes: maxBytes, backups: backups}
+	if err := l.open(); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// open opens the current file and reads its size
+func (l *FileLogger) open() error {
+	file, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open findings file: %w", err)
+	}
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return fmt.Errorf("failed to stat findings file: %w", err)
+	}
+	l.file, l.size = file, info.Size()
+	return nil
+}
+
+// Record appends record as a single JSON line, rotating the file first
+// if the line would take it past its size limit
+func (l *FileLogger) Record(ctx context.Context, record Record) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode findings record: %w", err)
+	}
+	line = append(line, '\n')
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// A failed open leaves no file; try again rather than failing every
+	// record from then on
+	if l.file == nil {
+		if err := l.open(); err != nil {
+			return err
+		}
+	}
+
+	if l.size > 0 && l.size+int64(len(line)) > l.maxBytes {
+		if err := l.rotate(); err != nil {
+			// Keep appending to the current file; the next record retries
+			log.Printf("⚠️  %v", err)
+			if l.file == nil {
+				return err
+			}
+		}
+	}
+
+	n, err := l.file.Write(line)
+	l.size += int64(n)
+	if err != nil {
+		return fmt.Errorf("failed to write findings record: %w", err)
+	}
+	return nil
+}
+
+// rotate moves the current file to path.1, shifting older backups, and
+// starts a new file. Without backups the file is truncated. If the move
+// fails the current file is reopened, so l.file is only left nil when
+// the file can't be opened at all.
+func (l *FileLogger) rotate() error {
+	err := l.file.Close()
+	l.file = nil
+	if err != nil {
+		return errors.Join(fmt.Errorf("failed to close findings file: %w", err), l.open())
+	}
+
+	if l.backups > 0 {
+		os.Remove(fmt.Sprintf("%s.%d", l.path, l.backups))
+		for i := l.backups - 1; i >= 1; i-- {
+			os.Rename(fmt.Sprintf("%s.%d", l.path, i), fmt.Sprintf("%s.%d", l.path, i+1))
+		}
+		if err := os.Rename(l.path, l.path+".1"); err != nil {
+			return errors.Join(fmt.Errorf("failed to rotate findings file: %w", err), l.open())
+		}
+	} else if err := os.Truncate(l.path, 0); err != nil {
+		return errors.Join(fmt.Errorf("failed to truncate findings file: %w", err), l.open())
+	}
+
+	return l.open()
+}
+
+// Close closes the underlying file
+func (l *FileLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.file.Close()
+}
@@ -0,0 +1,31 @@
+// Package findings keeps a durable local record of every processed PR and
+// its findings as JSON lines, independent of Slack and GitHub, for backup
+// and later ingestion.
+package findings
+
+import (
+	"context"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/audit"
+)
+
+// Record is the outcome of processing one PR event. Findings never
+// include the secrets themselves.
+type Record struct {
+	Time       time.Time       `json:"time"`
+	DeliveryID string          `json:"delivery_id,omitempty"`
+	Action     string          `json:"action,omitempty"`
+	Repository string          `json:"repository"`
+	PRNumber   int             `json:"pr_number"`
+	SHA        string          `json:"sha"`
+	Outcome    string          `json:"outcome"`
+	Error      string          `json:"error,omitempty"`
+	Findings   []audit.Finding `json:"findings"`
+}
+
+// Logger records processed PRs. Implementations must be safe for
+// concurrent use.
+type Logger interface {
+	Record(ctx context.Context, record Record) error
+}
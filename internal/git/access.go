@@ -0,0 +1,95 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/apierr"
+	"github.com/google/go-github/v57/github"
+)
+
+// CheckAccess verifies the token can read pull requests and write commit
+// statuses in each of repos ("owner/name"), so a misconfigured token fails
+// at startup rather than with a 403 on the first status. A token lacking
+// access fails with apierr.ErrAuth; other errors, such as an unreachable
+// API, don't say anything about the token. Classic tokens are checked for
+// the repo, public_repo or repo:status scope. Fine-grained tokens don't
+// report their permissions, so for them only read access and the user's
+// write access are checked; the statuses permission itself can't be
+// verified without writing a status.
+func (g *GitHubClient) CheckAccess(ctx context.Context, repos []string) error {
+	var resp *github.Response
+	err := withRetry(ctx, func() error {
+		var err error
+		_, resp, err = g.client.RateLimit.Get(ctx)
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("GitHub API check failed: %w", classify(err))
+	}
+	if err := checkScopes(resp.Header.Values("X-OAuth-Scopes")); err != nil {
+		return err
+	}
+
+	for _, fullName := range repos {
+		owner, name, _ := strings.Cut(fullName, "/")
+
+		var repo *github.Repository
+		err := withRetry(ctx, func() error {
+			var err error
+			repo, _, err = g.client.Repositories.Get(ctx, owner, name)
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("token cannot access %s, check that the repository exists and is shared with the token: %w", fullName, classify(err))
+		}
+		if perms := repo.GetPermissions(); perms != nil && !perms["push"] && !perms["admin"] {
+			return apierr.Wrap(apierr.ErrAuth, fmt.Errorf("token cannot write commit statuses to %s: write access is required", fullName))
+		}
+
+		err = withRetry(ctx, func() error {
+			_, _, err := g.client.PullRequests.List(ctx, owner, name, &github.PullRequestListOptions{
+				ListOptions: github.ListOptions{PerPage: 1},
+			})
+			return err
+		})
+		if err != nil {
+			if errors.Is(classify(err), apierr.ErrAuth) {
+				return fmt.Errorf("token cannot read pull requests in %s: grant the pull requests read permission: %w", fullName, classify(err))
+			}
+			return fmt.Errorf("failed to list pull requests in %s: %w", fullName, classify(err))
+		}
+	}
+
+	return nil
+}
+
+// checkScopes checks the X-OAuth-Scopes header of a classic token. The
+// header is missing for fine-grained and GitHub App tokens, which pass.
+func checkScopes(header []string) error {
+	if len(header) == 0 {
+		return nil
+	}
+
+	var scopes []string
+	for _, value := range header {
+		for _, scope := range strings.Split(value, ",") {
+			if scope = strings.TrimSpace(scope); scope != "" {
+				scopes = append(scopes, scope)
+			}
+		}
+	}
+	for _, scope := range scopes {
+		// public_repo covers statuses on public repositories only; a private
+		// one is caught by the per-repository checks
+		if scope == "repo" || scope == "public_repo" || scope == "repo:status" {
+			return nil
+		}
+	}
+	if len(scopes) == 0 {
+		scopes = []string{"none"}
+	}
+	return apierr.Wrap(apierr.ErrAuth, fmt.Errorf("token is missing the repo scope needed to post commit statuses (has: %s)", strings.Join(scopes, ", ")))
+}
@@ -0,0 +1,31 @@
+package git
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/Rishav176/GitReviewed/internal/apierr"
+)
+
+func TestCheckScopes(t *testing.T) {
+	tests := []struct {
+		header []string
+		ok     bool
+	}{
+		{nil, true}, // fine-grained and GitHub App tokens
+		{[]string{"repo, read:org"}, true},
+		{[]string{"public_repo"}, true},
+		{[]string{"read:org", "repo:status"}, true},
+		{[]string{"read:org, gist"}, false},
+		{[]string{""}, false},
+	}
+	for _, tt := range tests {
+		err := checkScopes(tt.header)
+		if tt.ok && err != nil {
+			t.Errorf("checkScopes(%q) = %v, want nil", tt.header, err)
+		}
+		if !tt.ok && !errors.Is(err, apierr.ErrAuth) {
+			t.Errorf("checkScopes(%q) = %v, want an auth error", tt.header, err)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package git
+
+import (
+	"regexp"
+	"strings"
+)
+
+// AttributesFile is the path of a repository's attributes file
+const AttributesFile = ".gitattributes"
+
+// attributeRule sets or unsets linguist-generated for matching paths
+type attributeRule struct {
+	pattern   *regexp.Regexp
+	generated bool
+}
+
+// Attributes holds the linguist-generated settings of a .gitattributes
+// file. A nil Attributes marks no files as generated.
+type Attributes struct {
+	rules []attributeRule
+}
+
+// ParseAttributes parses the linguist-generated settings from the content
+// of a .gitattributes file. Lines with other attributes, macros and
+// invalid patterns are ignored.
+func ParseAttributes(content string) *Attributes {
+	a := &Attributes{}
+	for _, line := range strings.Split(content, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") || strings.HasPrefix(fields[0], "[attr]") {
+			continue
+		}
+
+		for _, attr := range fields[1:] {
+			var generated bool
+			switch attr {
+			case "linguist-generated", "linguist-generated=true":
+				generated = true
+			case "-linguist-generated", "!linguist-generated", "linguist-generated=false":
+				generated = false
+			default:
+				continue
+			}
+
+			if pattern, err := attributePattern(fields[0]); err == nil {
+				a.rules = append(a.rules, attributeRule{pattern: pattern, generated: generated})
+			}
+		}
+	}
+	return a
+}
+
+// Generated reports whether filename is marked linguist-generated. As in
+// git, the last matching line wins.
+func (a *Attributes) Generated(filename string) bool {
+	if a == nil {
+		return false
+	}
+	for i := len(a.rules) - 1; i >= 0; i-- {
+		if a.rules[i].pattern.MatchString(filename) {
+			return a.rules[i].generated
+		}
+	}
+	return false
+}
+
+// attributePattern compiles a .gitattributes path pattern. A pattern
+// without a slash matches the base name at any depth; others are relative
+// to the repository root. "**" matches across directories.
+func attributePattern(pattern string) (*regexp.Regexp, error) {
+	var expr strings.Builder
+	expr.WriteString("^")
+	if !strings.Contains(strings.TrimSuffix(pattern, "/"), "/") {
+		expr.WriteString("(?:.*/)?")
+	}
+	pattern = strings.TrimPrefix(pattern, "/")
+
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case c == '*':
+			expr.WriteString("[^/]*")
+		case c == '?':
+			expr.WriteString("[^/]")
+		case c == '[':
+			end := strings.IndexByte(pattern[i+1:], ']')
+			if end < 0 {
+				expr.WriteString(`\[`)
+				continue
+			}
+			class := pattern[i+1 : i+1+end]
+			if strings.HasPrefix(class, "!") {
+				class = "^" + class[1:]
+			}
+			expr.WriteString("[" + class + "]")
+			i += end + 1
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			expr.WriteString(regexp.QuoteMeta(string(c)))
+		}
+	}
+
+	expr.WriteString("$")
+	return regexp.Compile(expr.String())
+}
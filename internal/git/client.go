@@ -10,10 +10,70 @@ import (
 type Client interface {
 	// GetPRDiff fetches the diff for a pull request
 	GetPRDiff(ctx context.Context, owner, repo string, prNumber int) ([]models.DiffFile, error)
-	
+
+	// EachPRFilePage fetches a pull request's files page by page, calling
+	// fn with each page so large PRs needn't be held in memory at once
+	EachPRFilePage(ctx context.Context, owner, repo string, prNumber int, fn func(files []models.DiffFile) error) error
+
+	// ListPRCommits lists the commits in a pull request
+	ListPRCommits(ctx context.Context, owner, repo string, prNumber int) ([]models.Commit, error)
+
+	// GetCompareDiff fetches the files changed between two commits
+	GetCompareDiff(ctx context.Context, owner, repo, base, head string) ([]models.DiffFile, error)
+
+	// CompareStatus returns how head relates to base: "ahead", "behind",
+	// "diverged" or "identical"
+	CompareStatus(ctx context.Context, owner, repo, base, head string) (string, error)
+
 	// VerifyWebhook verifies the webhook signature
 	VerifyWebhook(payload []byte, signature string) bool
-	
-	// PostCommitStatus posts a status check to a commit
-	PostCommitStatus(ctx context.Context, owner, repo, sha string, state, description, context string) error
-}
\ No newline at end of file
+
+	// PostCommitStatus posts a status check to a commit, linking to
+	// targetURL when set. Descriptions over GitHub's limit are shortened.
+	PostCommitStatus(ctx context.Context, owner, repo, sha string, state, description, context, targetURL string) error
+
+	// UpsertPRComment edits the PR comment containing marker, or creates
+	// one if none exists yet
+	UpsertPRComment(ctx context.Context, owner, repo string, prNumber int, marker, body string) error
+
+	// SubmitReview submits a formal PR review with the given event
+	// (ReviewApprove, ReviewRequestChanges or ReviewComment)
+	SubmitReview(ctx context.Context, owner, repo string, prNumber int, event, body string) error
+
+	// SubmitReviewComments submits a COMMENT review with inline comments
+	// on lines of the PR's diff at commitSHA
+	SubmitReviewComments(ctx context.Context, owner, repo string, prNumber int, commitSHA, body string, comments []models.ReviewComment) error
+
+	// ListReviewComments lists the inline review comments on the PR
+	ListReviewComments(ctx context.Context, owner, repo string, prNumber int) ([]models.ReviewComment, error)
+
+	// DismissReviews dismisses the change requests whose body contains
+	// marker and returns how many were dismissed
+	DismissReviews(ctx context.Context, owner, repo string, prNumber int, marker, message string) (int, error)
+
+	// LastReview returns the most recent review whose body contains
+	// marker, or nil if there is none
+	LastReview(ctx context.Context, owner, repo string, prNumber int, marker string) (*models.Review, error)
+
+	// GetFileContent fetches a file's raw content at the given ref
+	GetFileContent(ctx context.Context, owner, repo, path, ref string) (string, error)
+
+	// CreateIssue opens an issue and returns its URL
+	CreateIssue(ctx context.Context, owner, repo, title, body string, labels []string) (string, error)
+
+	// ListOpenIssues lists open issues (excluding PRs) that carry all labels
+	ListOpenIssues(ctx context.Context, owner, repo string, labels []string) ([]models.Issue, error)
+
+	// Ping verifies the API is reachable and the token is accepted
+	Ping(ctx context.Context) error
+
+	// CheckAccess verifies the token can read pull requests and write
+	// commit statuses in each of repos ("owner/name")
+	CheckAccess(ctx context.Context, repos []string) error
+
+	// ListOpenPRs lists the open pull requests in a repository
+	ListOpenPRs(ctx context.Context, owner, repo string) ([]models.PullRequest, error)
+
+	// GetPRInfo fetches basic pull request information
+	GetPRInfo(ctx context.Context, owner, repo string, prNumber int) (*models.PullRequest, error)
+}
@@ -0,0 +1,24 @@
+package git
+
+import (
+	"errors"
+
+	"github.com/Rishav176/GitReviewed/internal/apierr"
+	"github.com/google/go-github/v57/github"
+)
+
+// classify tags a GitHub API error with its apierr kind. The go-github
+// error stays in the chain for errors.As.
+func classify(err error) error {
+	var rateErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	var errResp *github.ErrorResponse
+
+	switch {
+	case errors.As(err, &rateErr), errors.As(err, &abuseErr):
+		return apierr.Wrap(apierr.ErrRateLimited, err)
+	case errors.As(err, &errResp) && errResp.Response != nil:
+		return apierr.Wrap(apierr.FromStatus(errResp.Response.StatusCode), err)
+	}
+	return apierr.FromNetwork(err)
+}
@@ -1,79 +1,445 @@
 package git
 
 import (
+	"bytes"
 	"context"
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
+	"log"
+	"net/http"
 	"strings"
+	"sync"
 
+	"github.com/Rishav176/GitReviewed/internal/apierr"
 	"github.com/Rishav176/GitReviewed/internal/models"
 	"github.com/google/go-github/v57/github"
 	"golang.org/x/oauth2"
 )
 
+// MaxFileContentBytes caps how much of a file GetFileContent will read
+const MaxFileContentBytes = 5 << 20
+
 // GitHubClient implements the Client interface for GitHub
 type GitHubClient struct {
 	client        *github.Client
 	webhookSecret string
+
+	loginMu     sync.Mutex
+	login       string
+	loginLooked bool
+}
+
+// NewGitHubClient creates a new GitHub client. API calls are made with
+// httpClient's transport and timeout; nil uses http.DefaultClient.
+func NewGitHubClient(token, webhookSecret string, httpClient *http.Client) *GitHubClient {
+	return &GitHubClient{
+		client:        github.NewClient(tokenClient(token, httpClient)),
+		webhookSecret: webhookSecret,
+	}
+}
+
+// NewEnterpriseClient creates a GitHub client for a GitHub Enterprise
+// Server instance. baseURL is the API root, e.g.
+// https://ghe.company.com/api/v3; "/api/v3" is added when missing.
+func NewEnterpriseClient(baseURL, token, webhookSecret string, httpClient *http.Client) (*GitHubClient, error) {
+	uploadURL := strings.TrimSuffix(strings.TrimSuffix(baseURL, "/"), "/api/v3")
+	client, err := github.NewClient(tokenClient(token, httpClient)).WithEnterpriseURLs(baseURL, uploadURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure GitHub Enterprise URL: %w", err)
+	}
+
+	return &GitHubClient{
+		client:        client,
+		webhookSecret: webhookSecret,
+	}, nil
 }
 
-// NewGitHubClient creates a new GitHub client
-func NewGitHubClient(token, webhookSecret string) *GitHubClient {
+// tokenClient returns an HTTP client that authenticates with token
+func tokenClient(token string, httpClient *http.Client) *http.Client {
 	ctx := context.Background()
+	if httpClient != nil {
+		ctx = context.WithValue(ctx, oauth2.HTTPClient, httpClient)
+	}
 	ts := oauth2.StaticTokenSource(
 		&oauth2.Token{AccessToken: token},
 	)
 	tc := oauth2.NewClient(ctx, ts)
-
-	return &GitHubClient{
-		client:        github.NewClient(tc),
-		webhookSecret: webhookSecret,
+	if httpClient != nil {
+		// oauth2 only reuses the transport, not the timeout
+		tc.Timeout = httpClient.Timeout
 	}
+	return tc
 }
 
-// PostCommitStatus posts a status check to a commit
-func (g *GitHubClient) PostCommitStatus(ctx context.Context, owner, repo, sha string, state, description, context string) error {
+// maxStatusDescription is the longest commit status description GitHub
+// keeps, in characters
+const maxStatusDescription = 140
+
+// PostCommitStatus posts a status check to a commit. Statuses are
+// idempotent per commit and context, so transient failures are retried.
+func (g *GitHubClient) PostCommitStatus(ctx context.Context, owner, repo, sha string, state, description, context, targetURL string) error {
 	status := &github.RepoStatus{
 		State:       github.String(state),
-		Description: github.String(description),
+		Description: github.String(truncateDescription(description)),
 		Context:     github.String(context),
 	}
+	if targetURL != "" {
+		status.TargetURL = github.String(targetURL)
+	}
 
-	_, _, err := g.client.Repositories.CreateStatus(ctx, owner, repo, sha, status)
+	err := withRetry(ctx, func() error {
+		_, _, err := g.client.Repositories.CreateStatus(ctx, owner, repo, sha, status)
+		return err
+	})
 	if err != nil {
-		return fmt.Errorf("failed to post commit status: %w", err)
+		return fmt.Errorf("failed to post commit status: %w", classify(err))
 	}
 
 	return nil
 }
 
+// truncateDescription shortens description to maxStatusDescription
+// characters, ending it with an ellipsis, rather than let GitHub cut it
+func truncateDescription(description string) string {
+	runes := []rune(description)
+	if len(runes) <= maxStatusDescription {
+		return description
+	}
+	return strings.TrimRight(string(runes[:maxStatusDescription-1]), " ") + "…"
+}
+
 // GetPRDiff fetches the diff for a pull request
 func (g *GitHubClient) GetPRDiff(ctx context.Context, owner, repo string, prNumber int) ([]models.DiffFile, error) {
-	// Get the list of files changed in the PR
+	var allFiles []models.DiffFile
+
+	err := g.EachPRFilePage(ctx, owner, repo, prNumber, func(files []models.DiffFile) error {
+		allFiles = append(allFiles, files...)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return allFiles, nil
+}
+
+// EachPRFilePage fetches the files changed in a pull request one page at a
+// time, calling fn with each page. An error from fn stops the listing.
+func (g *GitHubClient) EachPRFilePage(ctx context.Context, owner, repo string, prNumber int, fn func(files []models.DiffFile) error) error {
 	opts := &github.ListOptions{
 		PerPage: 100,
 	}
 
-	var allFiles []models.DiffFile
-
 	for {
 		files, resp, err := g.client.PullRequests.ListFiles(ctx, owner, repo, prNumber, opts)
 		if err != nil {
-			return nil, fmt.Errorf("failed to fetch PR files: %w", err)
+			return fmt.Errorf("failed to fetch PR files: %w", classify(err))
 		}
 
+		page := make([]models.DiffFile, 0, len(files))
 		for _, file := range files {
-			diffFile := models.DiffFile{
+			page = append(page, models.DiffFile{
 				Filename:  file.GetFilename(),
 				Status:    file.GetStatus(),
 				Additions: file.GetAdditions(),
 				Deletions: file.GetDeletions(),
 				Changes:   file.GetChanges(),
 				Patch:     file.GetPatch(),
+
+				PreviousFilename: file.GetPreviousFilename(),
+			})
+		}
+		if err := fn(page); err != nil {
+			return err
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return nil
+}
+
+// UpsertPRComment edits the PR comment containing marker, or creates one
+// if none exists yet. The marker is added to the body when missing so the
+// comment can be found again on the next run. Only comments by the token's
+// user are edited, so a marker quoted by someone else is left alone.
+func (g *GitHubClient) UpsertPRComment(ctx context.Context, owner, repo string, prNumber int, marker, body string) error {
+	if !strings.Contains(body, marker) {
+		body = marker + "\n" + body
+	}
+	login := g.authenticatedLogin(ctx)
+
+	opts := &github.IssueListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	for {
+		comments, resp, err := g.client.Issues.ListComments(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return fmt.Errorf("failed to list PR comments: %w", classify(err))
+		}
+
+		for _, comment := range comments {
+			if !strings.Contains(comment.GetBody(), marker) {
+				continue
+			}
+			if login != "" && !strings.EqualFold(comment.GetUser().GetLogin(), login) {
+				continue
+			}
+			_, _, err := g.client.Issues.EditComment(ctx, owner, repo, comment.GetID(), &github.IssueComment{
+				Body: github.String(body),
+			})
+			if err == nil {
+				return nil
+			}
+			// Without a known login the comment may be someone else's,
+			// which the token can't edit; look for ours instead
+			if err = classify(err); !errors.Is(err, apierr.ErrAuth) {
+				return fmt.Errorf("failed to update PR comment: %w", err)
 			}
-			allFiles = append(allFiles, diffFile)
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	_, _, err := g.client.Issues.CreateComment(ctx, owner, repo, prNumber, &github.IssueComment{
+		Body: github.String(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to create PR comment: %w", classify(err))
+	}
+
+	return nil
+}
+
+// authenticatedLogin returns the login of the token's user, or "" if it
+// can't be looked up, as with GitHub App installation tokens. The result
+// is remembered unless the lookup failed transiently.
+func (g *GitHubClient) authenticatedLogin(ctx context.Context) string {
+	g.loginMu.Lock()
+	defer g.loginMu.Unlock()
+
+	if g.loginLooked {
+		return g.login
+	}
+
+	user, _, err := g.client.Users.Get(ctx, "")
+	if err != nil {
+		err = classify(err)
+		log.Printf("Could not look up the authenticated GitHub user, PR comments won't be matched by author: %v", err)
+		g.loginLooked = !apierr.Retryable(err)
+		return ""
+	}
+	g.login, g.loginLooked = user.GetLogin(), true
+	return g.login
+}
+
+// Formal PR review events accepted by SubmitReview
+const (
+	ReviewApprove        = "APPROVE"
+	ReviewRequestChanges = "REQUEST_CHANGES"
+	ReviewComment        = "COMMENT"
+)
+
+// SubmitReview submits a formal PR review. GitHub rejects APPROVE and
+// REQUEST_CHANGES on PRs opened by the token's own user.
+func (g *GitHubClient) SubmitReview(ctx context.Context, owner, repo string, prNumber int, event, body string) error {
+	_, _, err := g.client.PullRequests.CreateReview(ctx, owner, repo, prNumber, &github.PullRequestReviewRequest{
+		Event: github.String(event),
+		Body:  github.String(body),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to submit PR review: %w", classify(err))
+	}
+
+	return nil
+}
+
+// SubmitReviewComments submits a COMMENT review on commitSHA with body and
+// an inline comment on the new-file line of each comment. Every line must
+// be part of the PR's diff or GitHub rejects the whole review.
+func (g *GitHubClient) SubmitReviewComments(ctx context.Context, owner, repo string, prNumber int, commitSHA, body string, comments []models.ReviewComment) error {
+	drafts := make([]*github.DraftReviewComment, 0, len(comments))
+	for _, comment := range comments {
+		drafts = append(drafts, &github.DraftReviewComment{
+			Path: github.String(comment.Path),
+			Line: github.Int(comment.Line),
+			Side: github.String("RIGHT"),
+			Body: github.String(comment.Body),
+		})
+	}
+
+	_, _, err := g.client.PullRequests.CreateReview(ctx, owner, repo, prNumber, &github.PullRequestReviewRequest{
+		CommitID: github.String(commitSHA),
+		Event:    github.String(ReviewComment),
+		Body:     github.String(body),
+		Comments: drafts,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to submit PR review comments: %w", classify(err))
+	}
+
+	return nil
+}
+
+// ListReviewComments lists the inline review comments on the PR. Comments
+// on lines that are no longer part of the diff have no line.
+func (g *GitHubClient) ListReviewComments(ctx context.Context, owner, repo string, prNumber int) ([]models.ReviewComment, error) {
+	opts := &github.PullRequestListCommentsOptions{
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var all []models.ReviewComment
+	for {
+		comments, resp, err := g.client.PullRequests.ListComments(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PR review comments: %w", classify(err))
+		}
+
+		for _, comment := range comments {
+			all = append(all, models.ReviewComment{
+				Path: comment.GetPath(),
+				Line: comment.GetLine(),
+				Body: comment.GetBody(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return all, nil
+}
+
+// DismissReviews dismisses the change requests whose body contains marker
+// and returns how many were dismissed
+func (g *GitHubClient) DismissReviews(ctx context.Context, owner, repo string, prNumber int, marker, message string) (int, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	dismissed := 0
+
+	for {
+		reviews, resp, err := g.client.PullRequests.ListReviews(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return dismissed, fmt.Errorf("failed to list PR reviews: %w", classify(err))
+		}
+
+		for _, review := range reviews {
+			if review.GetState() != "CHANGES_REQUESTED" || !strings.Contains(review.GetBody(), marker) {
+				continue
+			}
+			_, _, err := g.client.PullRequests.DismissReview(ctx, owner, repo, prNumber, review.GetID(), &github.PullRequestReviewDismissalRequest{
+				Message: github.String(message),
+			})
+			if err != nil {
+				return dismissed, fmt.Errorf("failed to dismiss PR review: %w", classify(err))
+			}
+			dismissed++
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return dismissed, nil
+}
+
+// reviewEvents maps review states to the event that submitted them
+var reviewEvents = map[string]string{
+	"APPROVED":          ReviewApprove,
+	"CHANGES_REQUESTED": ReviewRequestChanges,
+	"COMMENTED":         ReviewComment,
+}
+
+// LastReview returns the most recent review whose body contains marker, or
+// nil if there is none. A dismissed review has no event.
+func (g *GitHubClient) LastReview(ctx context.Context, owner, repo string, prNumber int, marker string) (*models.Review, error) {
+	opts := &github.ListOptions{PerPage: 100}
+	var last *models.Review
+
+	// Reviews are listed oldest first
+	for {
+		reviews, resp, err := g.client.PullRequests.ListReviews(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list PR reviews: %w", classify(err))
+		}
+
+		for _, review := range reviews {
+			if strings.Contains(review.GetBody(), marker) {
+				last = &models.Review{Event: reviewEvents[review.GetState()], CommitSHA: review.GetCommitID()}
+			}
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return last, nil
+}
+
+// GetFileContent fetches a file's raw content at the given ref. Files larger
+// than MaxFileContentBytes and binary files return an error.
+func (g *GitHubClient) GetFileContent(ctx context.Context, owner, repo, path, ref string) (string, error) {
+	rc, _, err := g.client.Repositories.DownloadContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		return "", fmt.Errorf("failed to download %s: %w", path, err)
+	}
+	defer rc.Close()
+
+	data, err := io.ReadAll(io.LimitReader(rc, MaxFileContentBytes+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	if len(data) > MaxFileContentBytes {
+		return "", fmt.Errorf("%s exceeds %d bytes", path, MaxFileContentBytes)
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return "", fmt.Errorf("%s is a binary file", path)
+	}
+
+	return string(data), nil
+}
+
+// GetCompareDiff fetches the files changed between two commits
+func (g *GitHubClient) GetCompareDiff(ctx context.Context, owner, repo, base, head string) ([]models.DiffFile, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var allFiles []models.DiffFile
+
+	for {
+		comparison, resp, err := g.client.Repositories.CompareCommits(ctx, owner, repo, base, head, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to compare commits: %w", classify(err))
+		}
+
+		for _, file := range comparison.Files {
+			allFiles = append(allFiles, models.DiffFile{
+				Filename:  file.GetFilename(),
+				Status:    file.GetStatus(),
+				Additions: file.GetAdditions(),
+				Deletions: file.GetDeletions(),
+				Changes:   file.GetChanges(),
+				Patch:     file.GetPatch(),
+
+				PreviousFilename: file.GetPreviousFilename(),
+			})
 		}
 
 		if resp.NextPage == 0 {
@@ -85,6 +451,107 @@ func (g *GitHubClient) GetPRDiff(ctx context.Context, owner, repo string, prNumb
 	return allFiles, nil
 }
 
+// CompareStatus returns how head relates to base: "ahead", "behind",
+// "diverged" or "identical"
+func (g *GitHubClient) CompareStatus(ctx context.Context, owner, repo, base, head string) (string, error) {
+	comparison, _, err := g.client.Repositories.CompareCommits(ctx, owner, repo, base, head, &github.ListOptions{PerPage: 1})
+	if err != nil {
+		return "", fmt.Errorf("failed to compare commits: %w", classify(err))
+	}
+	return comparison.GetStatus(), nil
+}
+
+// ListPRCommits lists the commits in a pull request
+func (g *GitHubClient) ListPRCommits(ctx context.Context, owner, repo string, prNumber int) ([]models.Commit, error) {
+	opts := &github.ListOptions{
+		PerPage: 100,
+	}
+
+	var allCommits []models.Commit
+
+	for {
+		commits, resp, err := g.client.PullRequests.ListCommits(ctx, owner, repo, prNumber, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch PR commits: %w", classify(err))
+		}
+
+		for _, commit := range commits {
+			allCommits = append(allCommits, models.Commit{
+				SHA:     commit.GetSHA(),
+				Message: commit.GetCommit().GetMessage(),
+				Author:  commit.GetAuthor().GetLogin(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allCommits, nil
+}
+
+// CreateIssue opens an issue and returns its URL
+func (g *GitHubClient) CreateIssue(ctx context.Context, owner, repo, title, body string, labels []string) (string, error) {
+	issue, _, err := g.client.Issues.Create(ctx, owner, repo, &github.IssueRequest{
+		Title:  github.String(title),
+		Body:   github.String(body),
+		Labels: &labels,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to create issue: %w", classify(err))
+	}
+
+	return issue.GetHTMLURL(), nil
+}
+
+// ListOpenIssues lists open issues (excluding PRs) that carry all labels
+func (g *GitHubClient) ListOpenIssues(ctx context.Context, owner, repo string, labels []string) ([]models.Issue, error) {
+	opts := &github.IssueListByRepoOptions{
+		State:       "open",
+		Labels:      labels,
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var allIssues []models.Issue
+
+	for {
+		issues, resp, err := g.client.Issues.ListByRepo(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list issues: %w", classify(err))
+		}
+
+		for _, issue := range issues {
+			if issue.IsPullRequest() {
+				continue
+			}
+			allIssues = append(allIssues, models.Issue{
+				Number:  issue.GetNumber(),
+				Title:   issue.GetTitle(),
+				Body:    issue.GetBody(),
+				HTMLURL: issue.GetHTMLURL(),
+			})
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
+	}
+
+	return allIssues, nil
+}
+
+// Ping verifies the API is reachable and the token is accepted. It uses
+// the rate limit endpoint, which does not count against the rate limit.
+func (g *GitHubClient) Ping(ctx context.Context) error {
+	if _, _, err := g.client.RateLimit.Get(ctx); err != nil {
+		return fmt.Errorf("GitHub API check failed: %w", classify(err))
+	}
+	return nil
+}
+
 // VerifyWebhook verifies the GitHub webhook signature
 func (g *GitHubClient) VerifyWebhook(payload []byte, signature string) bool {
 	// GitHub sends the signature as "sha256=<signature>"
@@ -109,10 +576,44 @@ func (g *GitHubClient) VerifyWebhook(payload []byte, signature string) bool {
 func (g *GitHubClient) GetPRInfo(ctx context.Context, owner, repo string, prNumber int) (*models.PullRequest, error) {
 	pr, _, err := g.client.PullRequests.Get(ctx, owner, repo, prNumber)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch PR info: %w", err)
+		return nil, fmt.Errorf("failed to fetch PR info: %w", classify(err))
+	}
+
+	info := toPullRequest(pr)
+	return &info, nil
+}
+
+// ListOpenPRs lists the open pull requests in a repository
+func (g *GitHubClient) ListOpenPRs(ctx context.Context, owner, repo string) ([]models.PullRequest, error) {
+	opts := &github.PullRequestListOptions{
+		State:       "open",
+		ListOptions: github.ListOptions{PerPage: 100},
+	}
+
+	var allPRs []models.PullRequest
+
+	for {
+		prs, resp, err := g.client.PullRequests.List(ctx, owner, repo, opts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list open PRs: %w", classify(err))
+		}
+
+		for _, pr := range prs {
+			allPRs = append(allPRs, toPullRequest(pr))
+		}
+
+		if resp.NextPage == 0 {
+			break
+		}
+		opts.Page = resp.NextPage
 	}
 
-	return &models.PullRequest{
+	return allPRs, nil
+}
+
+// toPullRequest converts a go-github pull request to the model type
+func toPullRequest(pr *github.PullRequest) models.PullRequest {
+	return models.PullRequest{
 		Number:    pr.GetNumber(),
 		Title:     pr.GetTitle(),
 		HTMLURL:   pr.GetHTMLURL(),
@@ -132,5 +633,7 @@ func (g *GitHubClient) GetPRInfo(ctx context.Context, owner, repo string, prNumb
 			Ref: pr.GetBase().GetRef(),
 			SHA: pr.GetBase().GetSHA(),
 		},
-	}, nil
-}
\ No newline at end of file
+		MergeCommitSHA: pr.GetMergeCommitSHA(),
+		Draft:          pr.GetDraft(),
+	}
+}
@@ -0,0 +1,38 @@
+package git
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestTruncateDescription(t *testing.T) {
+	exact := strings.Repeat("a", maxStatusDescription)
+	if got := truncateDescription(exact); got != exact {
+		t.Errorf("description of %d characters was changed to %q", maxStatusDescription, got)
+	}
+	if got := truncateDescription("✅ No secrets found"); got != "✅ No secrets found" {
+		t.Errorf("short description was changed to %q", got)
+	}
+
+	long := "❌ Found 3 blocking secret(s) in " + strings.Repeat("config/secrets-ü.yaml, ", 20)
+	got := truncateDescription(long)
+	if n := utf8.RuneCountInString(got); n != maxStatusDescription {
+		t.Errorf("truncated description has %d characters, want %d", n, maxStatusDescription)
+	}
+	if !utf8.ValidString(got) {
+		t.Error("truncated description isn't valid UTF-8")
+	}
+	if !strings.HasSuffix(got, "…") || !strings.HasPrefix(long, strings.TrimSuffix(got, "…")) {
+		t.Errorf("truncated description %q isn't a prefix ending with an ellipsis", got)
+	}
+}
+
+func TestTruncateDescriptionTrimsSpaceBeforeEllipsis(t *testing.T) {
+	long := strings.Repeat("a", maxStatusDescription-3) + "  " + strings.Repeat("b", 10)
+
+	got := truncateDescription(long)
+	if want := strings.Repeat("a", maxStatusDescription-3) + "…"; got != want {
+		t.Errorf("truncateDescription() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,49 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/apierr"
+	"github.com/google/go-github/v57/github"
+)
+
+// retryAttempts is how often idempotent GitHub calls are tried
+const retryAttempts = 4
+
+// retryBaseDelay is the delay before the first retry; tests shorten it
+var retryBaseDelay = 500 * time.Millisecond
+
+// withRetry calls fn until it succeeds, returns a non-transient error, or
+// retryAttempts is reached, doubling the delay between attempts. Only use
+// it for idempotent calls.
+func withRetry(ctx context.Context, fn func() error) error {
+	delay := retryBaseDelay
+	var err error
+	for attempt := 1; ; attempt++ {
+		err = fn()
+		if err == nil || !isTransient(err) || attempt == retryAttempts {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(delay):
+		}
+		delay *= 2
+	}
+}
+
+// isTransient reports whether err is worth retrying: server errors,
+// secondary rate limits and network failures
+func isTransient(err error) bool {
+	// The primary rate limit resets at a fixed time, usually too late for
+	// a retry to help
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		return false
+	}
+	return apierr.Retryable(classify(err))
+}
@@ -0,0 +1,101 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v57/github"
+)
+
+// statusError returns a GitHub API error with the given HTTP status
+func statusError(code int) error {
+	return &github.ErrorResponse{
+		Response: &http.Response{StatusCode: code, Request: &http.Request{}},
+		Message:  http.StatusText(code),
+	}
+}
+
+// fastRetries shortens the retry delay for the test
+func fastRetries(t *testing.T) {
+	t.Helper()
+	saved := retryBaseDelay
+	retryBaseDelay = time.Millisecond
+	t.Cleanup(func() { retryBaseDelay = saved })
+}
+
+func TestWithRetrySucceedsAfterTransientFailure(t *testing.T) {
+	fastRetries(t)
+
+	calls := 0
+	err := withRetry(context.Background(), func() error {
+		calls++
+		if calls == 1 {
+			return statusError(http.StatusBadGateway)
+		}
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("withRetry returned %v, want nil", err)
+	}
+	if calls != 2 {
+		t.Errorf("fn called %d times, want 2", calls)
+	}
+}
+
+func TestWithRetryReturnsPermanentErrorImmediately(t *testing.T) {
+	fastRetries(t)
+
+	want := statusError(http.StatusUnprocessableEntity)
+	calls := 0
+	err := withRetry(context.Background(), func() error {
+		calls++
+		return want
+	})
+	if !errors.Is(err, want) {
+		t.Fatalf("withRetry returned %v, want %v", err, want)
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+}
+
+func TestWithRetryGivesUpAfterRetryAttempts(t *testing.T) {
+	fastRetries(t)
+
+	calls := 0
+	err := withRetry(context.Background(), func() error {
+		calls++
+		return statusError(http.StatusServiceUnavailable)
+	})
+	if err == nil {
+		t.Fatal("withRetry returned nil, want the last error")
+	}
+	if calls != retryAttempts {
+		t.Errorf("fn called %d times, want %d", calls, retryAttempts)
+	}
+}
+
+func TestWithRetryStopsWhenContextIsCanceled(t *testing.T) {
+	// A long delay shows the retry isn't waited for
+	saved := retryBaseDelay
+	retryBaseDelay = time.Hour
+	t.Cleanup(func() { retryBaseDelay = saved })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	calls := 0
+	err := withRetry(ctx, func() error {
+		calls++
+		return statusError(http.StatusBadGateway)
+	})
+	if err == nil {
+		t.Fatal("withRetry returned nil, want the last error")
+	}
+	if calls != 1 {
+		t.Errorf("fn called %d times, want 1", calls)
+	}
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/activity"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// SetActivityStore sets where processed webhook events are recorded; nil
+// disables the history
+func (h *WebhookHandler) SetActivityStore(store activity.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.activity = store
+}
+
+// activityStore returns the current activity store, which may be nil
+func (h *WebhookHandler) activityStore() activity.Store {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.activity
+}
+
+// recordActivity records a processed event if the history is enabled
+func (h *WebhookHandler) recordActivity(event activity.Event) {
+	store := h.activityStore()
+	if store == nil {
+		return
+	}
+	if err := store.Record(context.Background(), event); err != nil {
+		log.Printf("Error recording webhook activity: %v", err)
+	}
+}
+
+// recordIgnored records an event that was received but not processed
+func (h *WebhookHandler) recordIgnored(deliveryID, eventType, action, repo string) {
+	h.recordActivity(activity.Event{
+		Time:       time.Now(),
+		DeliveryID: deliveryID,
+		Event:      eventType,
+		Action:     action,
+		Repository: repo,
+		Outcome:    activity.OutcomeIgnored,
+	})
+}
+
+// trackPullRequest processes a PR and records the outcome
+func (h *WebhookHandler) trackPullRequest(ctx context.Context, deliveryID string, payload models.WebhookPayload) (prSummary, error) {
+	start := time.Now()
+	summary, err := h.processPullRequest(ctx, payload)
+
+	event := activity.Event{
+		Time:       start,
+		DeliveryID: deliveryID,
+		Event:      "pull_request",
+		Action:     payload.Action,
+		Repository: payload.Repository.FullName,
+		PRNumber:   payload.PullRequest.Number,
+		Findings:   len(summary.Findings),
+		Outcome:    activity.OutcomeClean,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	switch {
+	case err != nil:
+		event.Outcome = activity.OutcomeError
+		event.Error = err.Error()
+	case summary.Blocking > 0:
+		event.Outcome = activity.OutcomeBlocked
+	case len(summary.Findings) > 0:
+		event.Outcome = activity.OutcomeFindings
+	}
+	h.recordActivity(event)
+	h.recordFindings(ctx, deliveryID, payload, summary, event)
+
+	return summary, err
+}
+
+// trackPush processes a push and records the outcome
+func (h *WebhookHandler) trackPush(deliveryID string, payload models.PushPayload) {
+	start := time.Now()
+	result, err := h.processPush(payload)
+
+	event := activity.Event{
+		Time:       start,
+		DeliveryID: deliveryID,
+		Event:      "push",
+		Repository: payload.Repository.FullName,
+		Ref:        payload.Ref,
+		Findings:   len(result.Issues),
+		Outcome:    activity.OutcomeClean,
+		DurationMS: time.Since(start).Milliseconds(),
+	}
+	switch {
+	case err != nil:
+		event.Outcome = activity.OutcomeError
+		event.Error = err.Error()
+	case result.Found:
+		event.Outcome = activity.OutcomeFindings
+	}
+	h.recordActivity(event)
+}
+
+// History lists recently processed webhook events, newest first. It
+// requires the admin token; ?limit=N returns only the latest N.
+func (h *WebhookHandler) History(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	store := h.activityStore()
+	if store == nil {
+		http.Error(w, "History is disabled", http.StatusNotFound)
+		return
+	}
+
+	limit := 0
+	if value := r.URL.Query().Get("limit"); value != "" {
+		n, err := strconv.Atoi(value)
+		if err != nil || n < 1 {
+			http.Error(w, "limit must be a positive number", http.StatusBadRequest)
+			return
+		}
+		limit = n
+	}
+
+	events, err := store.Recent(r.Context(), limit)
+	if err != nil {
+		http.Error(w, "Failed to read history", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"events": events,
+	})
+}
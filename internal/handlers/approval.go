@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/approval"
+	"github.com/Rishav176/GitReviewed/internal/audit"
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// awaitingApprovalDescription is the status of a PR whose secrets are gone
+// but whose block hasn't been approved yet
+const awaitingApprovalDescription = "🔒 Secrets removed - awaiting security approval (/gitreviewed approve)"
+
+// newApprovalStore creates the store of pending approvals: a file at
+// APPROVAL_STATE_FILE, or memory
+func newApprovalStore(cfg *config.Config) (approval.Store, error) {
+	if cfg.ApprovalStateFile == "" {
+		return approval.NewMemoryStore(), nil
+	}
+	return approval.NewFileStore(cfg.ApprovalStateFile)
+}
+
+// blockApproval marks the PR's block as needing approval
+func (h *WebhookHandler) blockApproval(key string) {
+	if err := h.approvals.Block(key); err != nil {
+		log.Printf("🚨 Failed to save the pending approval of %s: %v", key, err)
+	}
+}
+
+// clearApproval forgets the PR's pending approval and reports whether it
+// had one
+func (h *WebhookHandler) clearApproval(key string) bool {
+	was, err := h.approvals.Clear(key)
+	if err != nil {
+		log.Printf("🚨 Failed to save the cleared approval of %s: %v", key, err)
+	}
+	return was
+}
+
+// blockApprover reports whether login may approve clearing blocks
+func blockApprover(cfg *config.Config, login string) bool {
+	for _, approver := range cfg.BlockApprovers {
+		if strings.EqualFold(approver, login) {
+			return true
+		}
+	}
+	return false
+}
+
+// approveBlock records an approval of the PR's block by approver and
+// rescans the PR, so its status passes if the secrets are gone
+func (h *WebhookHandler) approveBlock(ctx context.Context, deliveryID string, repo models.Repository, pr models.PullRequest, approver string) {
+	key := fmt.Sprintf("%s#%d", repo.FullName, pr.Number)
+	if !h.clearApproval(key) {
+		log.Printf("PR #%d: %s approved, but no block is awaiting approval", pr.Number, approver)
+	} else {
+		log.Printf("PR #%d: block approved by %s", pr.Number, approver)
+		h.auditApproval(ctx, repo, pr, approver)
+	}
+
+	h.trackPullRequest(ctx, deliveryID, models.WebhookPayload{
+		Action:      commandApprove,
+		PullRequest: pr,
+		Repository:  repo,
+	})
+}
+
+// auditApproval records an approved block in the audit log
+func (h *WebhookHandler) auditApproval(ctx context.Context, repo models.Repository, pr models.PullRequest, approver string) {
+	h.mu.RLock()
+	logger := h.auditLog
+	h.mu.RUnlock()
+	if logger == nil {
+		return
+	}
+
+	event := audit.Event{
+		Time:       time.Now().UTC(),
+		Action:     audit.ActionApproved,
+		Repository: repo.FullName,
+		PRNumber:   pr.Number,
+		SHA:        pr.Head.SHA,
+		Actor:      approver,
+		Findings:   []audit.Finding{},
+	}
+	if err := logger.Record(ctx, event); err != nil {
+		log.Printf("🚨 Failed to write audit event for %s#%d: %v", repo.FullName, pr.Number, err)
+	}
+}
+
+// Approve handles POST /approve?repo=owner/name&pr=N, approving a PR's
+// block like the /gitreviewed approve command
+func (h *WebhookHandler) Approve(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	fullName := r.URL.Query().Get("repo")
+	owner, name, ok := strings.Cut(fullName, "/")
+	if !ok || owner == "" || name == "" {
+		http.Error(w, "repo must be owner/name", http.StatusBadRequest)
+		return
+	}
+	number, err := strconv.Atoi(r.URL.Query().Get("pr"))
+	if err != nil || number < 1 {
+		http.Error(w, "pr must be a PR number", http.StatusBadRequest)
+		return
+	}
+
+	pr, err := h.gitClient.GetPRInfo(r.Context(), owner, name, number)
+	if err != nil {
+		log.Printf("Error fetching %s#%d for approval: %v", fullName, number, err)
+		http.Error(w, "Failed to fetch the PR", http.StatusBadGateway)
+		return
+	}
+	if pr.State != "open" {
+		http.Error(w, "PR is not open", http.StatusConflict)
+		return
+	}
+
+	repo := models.Repository{
+		Name:     name,
+		FullName: fullName,
+		Owner:    models.User{Login: owner},
+	}
+	go h.approveBlock(context.Background(), "", repo, *pr, "admin")
+
+	w.WriteHeader(http.StatusAccepted)
+	w.Write([]byte("Approval accepted"))
+}
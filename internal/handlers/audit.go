@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/audit"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// blockTracker remembers which PRs are currently blocked so that clearing
+// a block can be audited. It is kept in memory, so a block that is cleared
+// after a restart is not recorded as cleared.
+type blockTracker struct {
+	mu      sync.Mutex
+	blocked map[string]bool
+}
+
+// update records whether key is blocked and reports whether it was
+// blocked before
+func (t *blockTracker) update(key string, blocked bool) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.blocked == nil {
+		t.blocked = make(map[string]bool)
+	}
+	was := t.blocked[key]
+	if blocked {
+		t.blocked[key] = true
+	} else {
+		delete(t.blocked, key)
+	}
+	return was
+}
+
+// SetAuditLogger sets where blocking decisions are recorded; nil disables
+// auditing
+func (h *WebhookHandler) SetAuditLogger(logger audit.Logger) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.auditLog = logger
+}
+
+// auditDecision records a PR being blocked, or a previous block being
+// cleared, in the audit log
+func (h *WebhookHandler) auditDecision(ctx context.Context, repo models.Repository, pr models.PullRequest, threshold string, blocking []models.SecurityIssue) {
+	h.mu.RLock()
+	logger := h.auditLog
+	h.mu.RUnlock()
+	if logger == nil {
+		return
+	}
+
+	key := fmt.Sprintf("%s#%d", repo.FullName, pr.Number)
+	wasBlocked := h.blocks.update(key, len(blocking) > 0)
+
+	action := audit.ActionBlocked
+	if len(blocking) == 0 {
+		if !wasBlocked {
+			return
+		}
+		action = audit.ActionCleared
+	}
+
+	event := audit.Event{
+		Time:       time.Now().UTC(),
+		Action:     action,
+		Repository: repo.FullName,
+		PRNumber:   pr.Number,
+		SHA:        pr.Head.SHA,
+		Threshold:  threshold,
+		Findings:   audit.Findings(blocking),
+	}
+	if err := logger.Record(ctx, event); err != nil {
+		log.Printf("🚨 Failed to write audit event for %s: %v", key, err)
+	}
+}
@@ -0,0 +1,56 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"strings"
+	"sync"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// ScanOpenPullRequests runs the PR pipeline for every open PR in the
+// configured startup scan repos, so PRs opened before deployment get a
+// status and Slack summary without waiting for a new push
+func (h *WebhookHandler) ScanOpenPullRequests(ctx context.Context) {
+	cfg := h.currentConfig()
+
+	sem := make(chan struct{}, cfg.StartupScanConcurrency)
+	var wg sync.WaitGroup
+
+	for _, fullName := range cfg.StartupScanRepos {
+		owner, name, _ := strings.Cut(fullName, "/")
+
+		prs, err := h.gitClient.ListOpenPRs(ctx, owner, name)
+		if err != nil {
+			log.Printf("Startup scan: error listing open PRs in %s: %v", fullName, err)
+			continue
+		}
+		log.Printf("Startup scan: %d open PR(s) in %s", len(prs), fullName)
+
+		repo := models.Repository{
+			Name:     name,
+			FullName: fullName,
+			Owner:    models.User{Login: owner},
+		}
+		for _, pr := range prs {
+			if pr.Draft && !cfg.ScanDrafts {
+				continue
+			}
+			sem <- struct{}{}
+			wg.Add(1)
+			go func(pr models.PullRequest) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				h.trackPullRequest(ctx, "", models.WebhookPayload{
+					Action:      "startup_scan",
+					PullRequest: pr,
+					Repository:  repo,
+				})
+			}(pr)
+		}
+	}
+
+	wg.Wait()
+	log.Printf("Startup scan complete")
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"regexp"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// PR comment commands, also used as the action of the events they start
+const (
+	// commandRescan scans the PR for secrets again without an AI review
+	commandRescan = "rescan"
+	// commandReview runs the whole pipeline with a fresh AI review
+	commandReview = "review"
+	// commandApprove approves clearing a block and rescans like
+	// commandRescan
+	commandApprove = "approve"
+)
+
+// commandPattern matches a command on its own line of a comment
+var commandPattern = regexp.MustCompile(`(?m)^\s*/gitreviewed\s+(rescan|review|approve)\s*$`)
+
+// commandAssociations lists the repository associations allowed to run
+// commands; the PR's author can always run them
+var commandAssociations = map[string]bool{
+	"OWNER":        true,
+	"MEMBER":       true,
+	"COLLABORATOR": true,
+}
+
+// errRescanOnly is reported for the AI review of a PR rescanned with
+// /gitreviewed rescan or approve
+var errRescanOnly = fmt.Errorf("%w: only secrets were rescanned on request", errReviewSkipped)
+
+// parseCommand returns the first command in a comment, or ""
+func parseCommand(body string) string {
+	if m := commandPattern.FindStringSubmatch(body); m != nil {
+		return m[1]
+	}
+	return ""
+}
+
+// handleIssueCommentEvent runs a /gitreviewed command from a PR comment
+func (h *WebhookHandler) handleIssueCommentEvent(w http.ResponseWriter, body []byte, deliveryID string) {
+	var payload models.IssueCommentPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		log.Printf("Error parsing issue comment payload: %v", err)
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	command := parseCommand(payload.Comment.Body)
+	if payload.Action != "created" || payload.Issue.PullRequest == nil || command == "" || payload.Comment.User.Type == "Bot" {
+		h.recordIgnored(deliveryID, "issue_comment", payload.Action, payload.Repository.FullName)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Comment ignored"))
+		return
+	}
+
+	commenter := payload.Comment.User.Login
+	if command == commandApprove && !blockApprover(h.currentConfig(), commenter) {
+		log.Printf("Ignoring /gitreviewed approve on PR #%d from %s, who is not a block approver", payload.Issue.Number, commenter)
+		h.recordIgnored(deliveryID, "issue_comment", command, payload.Repository.FullName)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Commenter may not approve blocks"))
+		return
+	}
+	if command != commandApprove && !commandAssociations[payload.Comment.AuthorAssociation] && commenter != payload.Issue.User.Login {
+		log.Printf("Ignoring /gitreviewed %s on PR #%d from %s (%s)", command, payload.Issue.Number, commenter, payload.Comment.AuthorAssociation)
+		h.recordIgnored(deliveryID, "issue_comment", command, payload.Repository.FullName)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Commenter may not run commands"))
+		return
+	}
+
+	log.Printf("PR #%d: %s requested /gitreviewed %s", payload.Issue.Number, commenter, command)
+	go h.runCommand(deliveryID, command, payload)
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Command accepted"))
+}
+
+// runCommand fetches the PR a command was posted on and processes it
+func (h *WebhookHandler) runCommand(deliveryID, command string, payload models.IssueCommentPayload) {
+	ctx := context.Background()
+	owner, repo := payload.Repository.Owner.Login, payload.Repository.Name
+
+	pr, err := h.gitClient.GetPRInfo(ctx, owner, repo, payload.Issue.Number)
+	if err != nil {
+		log.Printf("Error fetching PR #%d for /gitreviewed %s: %v", payload.Issue.Number, command, err)
+		h.reportFailure(fmt.Sprintf("Failed to fetch %s/%s#%d for /gitreviewed %s", owner, repo, payload.Issue.Number, command), err)
+		return
+	}
+	if pr.State != "open" {
+		log.Printf("Ignoring /gitreviewed %s on PR #%d, which is %s", command, pr.Number, pr.State)
+		return
+	}
+
+	if command == commandApprove {
+		h.approveBlock(ctx, deliveryID, payload.Repository, *pr, payload.Comment.User.Login)
+		return
+	}
+
+	h.trackPullRequest(ctx, deliveryID, models.WebhookPayload{
+		Action:      command,
+		PullRequest: *pr,
+		Repository:  payload.Repository,
+	})
+}
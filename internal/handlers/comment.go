@@ -0,0 +1,97 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/ai"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// summaryCommentMarker identifies the PR comment GitReviewed keeps updated
+const summaryCommentMarker = "<!-- gitreviewed:summary -->"
+
+// maxCommentReviewChars keeps the embedded AI review well under GitHub's
+// 65536 character comment limit
+const maxCommentReviewChars = 50000
+
+// buildPRComment renders the markdown summary comment for a PR
+func buildPRComment(reviewCtx models.ReviewContext, blockingCount int, review models.ReviewResult, aiErr error) string {
+	var b strings.Builder
+
+	b.WriteString(summaryCommentMarker + "\n")
+	b.WriteString("## 🔍 GitReviewed Summary\n\n")
+	b.WriteString(fmt.Sprintf("_Last updated for commit `%s`_\n\n", models.ShortSHA(reviewCtx.PullRequest.Head.SHA)))
+
+	// Secret scan results
+	b.WriteString("### Secret Scan\n\n")
+	issues := reviewCtx.ScanResult.Issues
+	switch {
+	case len(issues) == 0:
+		b.WriteString(fmt.Sprintf("✅ No secrets detected in %d file(s).\n\n", reviewCtx.ScanResult.TotalFiles))
+	case blockingCount > 0:
+		b.WriteString(fmt.Sprintf("❌ Found %d issue(s), %d blocking. Remove these secrets and rotate them before merging.\n\n", len(issues), blockingCount))
+	default:
+		b.WriteString(fmt.Sprintf("⚠️ Found %d non-blocking issue(s). Review recommended.\n\n", len(issues)))
+	}
+
+	if n := len(reviewCtx.ScanResult.UnscannedFiles); n > 0 {
+		b.WriteString(fmt.Sprintf("⚠️ %d file(s) were too large or binary and could not be scanned.\n\n", n))
+	}
+
+	if len(issues) > 0 {
+		b.WriteString("| Severity | Type | File | Line | Fingerprint |\n")
+		b.WriteString("|---|---|---|---|---|\n")
+		for _, issue := range issues {
+			location := fmt.Sprintf("`%s`", issue.FilePath)
+			if issue.CommitSHA != "" {
+				location = "commit message " + models.ShortSHA(issue.CommitSHA)
+			}
+			severity := issue.Severity
+			if issue.OriginalSeverity != "" {
+				severity += fmt.Sprintf(" (was %s)", issue.OriginalSeverity)
+			}
+			b.WriteString(fmt.Sprintf("| %s | %s | %s | %s | `%s` |\n", severity, issue.Type+issue.VerificationNote(), location, issue.Line(), issue.Fingerprint))
+		}
+		b.WriteString("\n")
+	}
+
+	// AI review results
+	if errors.Is(aiErr, errAIDisabled) {
+		return b.String()
+	}
+	b.WriteString("### AI Review\n\n")
+	if errors.Is(aiErr, errReviewSkipped) {
+		b.WriteString(fmt.Sprintf("_%s._\n", aiErr))
+		return b.String()
+	}
+	if errors.Is(aiErr, ai.ErrCircuitOpen) {
+		b.WriteString("_AI review is temporarily unavailable after repeated failures and will resume automatically._\n")
+		return b.String()
+	}
+	if aiErr != nil {
+		b.WriteString("_AI review could not be completed for this commit._\n")
+		return b.String()
+	}
+
+	if n := len(review.Failed); n > 0 {
+		b.WriteString(fmt.Sprintf("⚠️ %d of %d file(s) could not be reviewed.\n\n", n, review.Reviewed+n))
+	}
+	if n := reviewCtx.UnreviewedFiles; n > 0 {
+		b.WriteString(fmt.Sprintf("⚠️ %d lower-priority file(s) were left out of the AI review by `REVIEW_MAX_FILES`.\n\n", n))
+	}
+	if review.Verdict != "" {
+		b.WriteString(fmt.Sprintf("**Verdict:** `%s`\n\n", review.Verdict))
+	}
+
+	aiReview := review.ReviewText
+	if len(aiReview) > maxCommentReviewChars {
+		aiReview = aiReview[:maxCommentReviewChars] + "\n\n_... review truncated_"
+	}
+	b.WriteString("<details>\n<summary>Show AI review</summary>\n\n")
+	b.WriteString(aiReview)
+	b.WriteString("\n\n</details>\n")
+
+	return b.String()
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"sync"
+	"time"
+)
+
+// debouncer delays work until no newer work for the same key has arrived
+// for a window, so a burst of events runs once with the latest one
+type debouncer struct {
+	mu      sync.Mutex
+	pending map[string]*pendingRun
+}
+
+// pendingRun is work waiting for its window to pass
+type pendingRun struct {
+	timer *time.Timer
+	id    string
+	fn    func()
+}
+
+// add schedules fn to run after window unless add is called again for key
+// first. It returns the id of the work fn replaces, or an empty string.
+func (d *debouncer) add(key, id string, window time.Duration, fn func()) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.pending == nil {
+		d.pending = make(map[string]*pendingRun)
+	}
+
+	replaced := ""
+	if prev, ok := d.pending[key]; ok && prev.timer.Stop() {
+		replaced = prev.id
+	}
+
+	run := &pendingRun{id: id, fn: fn}
+	run.timer = time.AfterFunc(window, func() {
+		d.mu.Lock()
+		if d.pending[key] == run {
+			delete(d.pending, key)
+		}
+		d.mu.Unlock()
+		fn()
+	})
+	d.pending[key] = run
+	return replaced
+}
+
+// cancel drops the work waiting for key and returns its id, or an empty
+// string if none was waiting
+func (d *debouncer) cancel(key string) string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	run, ok := d.pending[key]
+	if !ok || !run.timer.Stop() {
+		return ""
+	}
+	delete(d.pending, key)
+	return run.id
+}
+
+// flush runs all waiting work now instead of after its window and returns
+// once it has finished, so a shutdown doesn't lose it. Work whose window
+// has already passed is running on its own and isn't waited for.
+func (d *debouncer) flush() {
+	d.mu.Lock()
+	var runs []func()
+	for key, run := range d.pending {
+		if run.timer.Stop() {
+			runs = append(runs, run.fn)
+		}
+		delete(d.pending, key)
+	}
+	d.mu.Unlock()
+
+	var wg sync.WaitGroup
+	for _, fn := range runs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			fn()
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDebouncerCancel(t *testing.T) {
+	var d debouncer
+	var ran atomic.Bool
+	d.add("octo-org/app#7", "delivery-1", 20*time.Millisecond, func() { ran.Store(true) })
+
+	if id := d.cancel("octo-org/app#7"); id != "delivery-1" {
+		t.Errorf("cancel = %q, want delivery-1", id)
+	}
+	if id := d.cancel("octo-org/app#7"); id != "" {
+		t.Errorf("second cancel = %q, want nothing left to cancel", id)
+	}
+	time.Sleep(50 * time.Millisecond)
+	if ran.Load() {
+		t.Error("cancelled work ran")
+	}
+}
+
+func TestDebouncerFlush(t *testing.T) {
+	var d debouncer
+	var ran atomic.Int32
+	d.add("octo-org/app#7", "delivery-1", time.Hour, func() { ran.Add(1) })
+	d.add("octo-org/app#8", "delivery-2", time.Hour, func() { ran.Add(1) })
+
+	d.flush()
+	if n := ran.Load(); n != 2 {
+		t.Errorf("flush ran %d waiting event(s), want 2", n)
+	}
+	if id := d.cancel("octo-org/app#7"); id != "" {
+		t.Errorf("cancel after flush = %q, want nothing left waiting", id)
+	}
+}
@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/digest"
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/scanner"
+)
+
+// SetDigestStore sets where PR findings are collected for the digest; nil
+// disables the digest
+func (h *WebhookHandler) SetDigestStore(store digest.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.digest = store
+}
+
+// digestStore returns the current digest store, which may be nil
+func (h *WebhookHandler) digestStore() digest.Store {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.digest
+}
+
+// recordDigest replaces the PR's findings in the digest; a PR without
+// findings is dropped from it
+func (h *WebhookHandler) recordDigest(repo models.Repository, pr models.PullRequest, issues []models.SecurityIssue) {
+	if store := h.digestStore(); store != nil {
+		store.Update(models.DigestEntry{
+			Repository:  repo,
+			PullRequest: pr,
+			Issues:      issues,
+			UpdatedAt:   time.Now(),
+		})
+	}
+}
+
+// forgetDigest drops a closed PR from the digest
+func (h *WebhookHandler) forgetDigest(repo string, number int) {
+	if store := h.digestStore(); store != nil {
+		store.Remove(repo, number)
+	}
+}
+
+// digestOnly reports whether the alert for issues is left to the digest
+// because none of them reaches cfg.DigestImmediateSeverity
+func digestOnly(cfg *config.Config, issues []models.SecurityIssue) bool {
+	if !cfg.Digest {
+		return false
+	}
+	for _, issue := range issues {
+		if scanner.SeverityRank(issue.Severity) >= scanner.SeverityRank(cfg.DigestImmediateSeverity) {
+			return false
+		}
+	}
+	return true
+}
+
+// RunDigest posts the findings digest every day at cfg.DigestTime until
+// ctx is done
+func (h *WebhookHandler) RunDigest(ctx context.Context) {
+	for {
+		next, err := digest.NextRun(time.Now(), h.currentConfig().DigestTime)
+		if err != nil {
+			log.Printf("Digest disabled: %v", err)
+			return
+		}
+		log.Printf("Next secrets digest at %s", next.Format(time.RFC1123))
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Until(next)):
+		}
+		h.sendDigest()
+	}
+}
+
+// sendDigest posts the open PRs with outstanding findings, if any
+func (h *WebhookHandler) sendDigest() {
+	store := h.digestStore()
+	if store == nil || !h.currentConfig().Digest {
+		return
+	}
+
+	entries := store.Entries()
+	if len(entries) == 0 {
+		log.Printf("No open PRs with findings, skipping the digest")
+		return
+	}
+
+	log.Printf("Sending secrets digest for %d PR(s)", len(entries))
+	if err := h.notifier.SendDigest(entries); err != nil {
+		log.Printf("Error sending secrets digest: %v", err)
+		h.reportFailure("Failed to send the secrets digest", err)
+	}
+}
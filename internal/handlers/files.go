@@ -0,0 +1,181 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/ai"
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/git"
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/scanner"
+)
+
+// prFiles is what processing keeps of a PR's files while scanning them
+// page by page: the files for the AI review, and totals over every file
+// the review considers, so the size checks see the whole PR
+type prFiles struct {
+	review    []models.DiffFile       // at most cfg.ReviewMaxFiles files
+	total     int                     // files considered for review
+	generated int                     // files left out as generated
+	lines     int                     // lines added and deleted
+	codeLines int                     // lines added and deleted in code files
+	newLines  map[string]map[int]bool // lines each diff shows, for inline comments
+}
+
+// unreviewed returns how many files the review cap left out
+func (f prFiles) unreviewed() int {
+	return f.total - len(f.review)
+}
+
+// scanPRFiles scans every file in a PR for secrets as each page arrives,
+// so huge PRs are never held in memory at once. Files that generated marks
+// as generated are left out of the AI review. Of the rest, all are kept
+// for review, or the cfg.ReviewMaxFiles highest-priority ones when the PR
+// is larger.
+func (h *WebhookHandler) scanPRFiles(ctx context.Context, cfg *config.Config, owner, repo string, pr models.PullRequest, generated *git.Attributes) (models.ScanResult, prFiles, error) {
+	result := models.ScanResult{}
+	var files prFiles
+	if cfg.AIInlineComments {
+		files.newLines = make(map[string]map[int]bool)
+	}
+
+	err := h.gitClient.EachPRFilePage(ctx, owner, repo, pr.Number, func(page []models.DiffFile) error {
+		// Fetch full content for files without a patch
+		scanFiles, unscanned := h.resolveMissingPatches(ctx, owner, repo, pr.Head.SHA, page, cfg.ScanRenamedFiles)
+		pageResult := h.secretScanner.ScanFiles(scanFiles)
+
+		result.Issues = append(result.Issues, pageResult.Issues...)
+		result.UnscannedFiles = append(result.UnscannedFiles, unscanned...)
+		result.TotalFiles += len(page)
+
+		reviewable := withoutGenerated(page, generated)
+		files.generated += len(page) - len(reviewable)
+		for _, file := range reviewable {
+			files.total++
+			files.lines += file.Additions + file.Deletions
+			if !ai.SkipReview(file.Filename) {
+				files.codeLines += file.Additions + file.Deletions
+			}
+			if files.newLines != nil {
+				files.newLines[file.Filename] = file.NewLines()
+			}
+		}
+		files.review = capReviewFiles(cfg, append(files.review, reviewable...))
+		return nil
+	})
+	if err != nil {
+		return models.ScanResult{}, prFiles{}, err
+	}
+
+	if n := files.unreviewed(); n > 0 {
+		log.Printf("PR #%d has %d files; AI review limited to %d", pr.Number, files.total, len(files.review))
+	}
+
+	scanner.SortIssues(result.Issues)
+	result.Found = len(result.Issues) > 0
+	result.ScannedAt = time.Now()
+	return result, files, nil
+}
+
+// capReviewFiles keeps the cfg.ReviewMaxFiles highest-priority files
+func capReviewFiles(cfg *config.Config, files []models.DiffFile) []models.DiffFile {
+	if cfg.ReviewMaxFiles <= 0 || len(files) <= cfg.ReviewMaxFiles {
+		return files
+	}
+	sorted := ai.SortFiles(files, ai.Priority(cfg.ReviewPriority), cfg.ReviewPriorityPaths)
+	return sorted[:cfg.ReviewMaxFiles]
+}
+
+// reviewSkipReason returns why the AI review of a PR changing lines lines
+// should be skipped, or "" when it should run
+func reviewSkipReason(cfg *config.Config, lines int) string {
+	if cfg.ReviewSkipLines <= 0 {
+		return ""
+	}
+
+	if lines > cfg.ReviewSkipLines {
+		return fmt.Sprintf("PR changes %d lines, over the %d-line review limit", lines, cfg.ReviewSkipLines)
+	}
+	return ""
+}
+
+// smallPRReason returns why a PR changing too few lines of code to be worth
+// an AI review is skipped, or "" when it should run. codeLines leaves out
+// non-code files such as lockfiles.
+func smallPRReason(cfg *config.Config, codeLines int) string {
+	if cfg.MinReviewChanges <= 0 {
+		return ""
+	}
+
+	if codeLines < cfg.MinReviewChanges {
+		return fmt.Sprintf("PR too small to warrant AI review (%d changed line(s) of code, minimum %d)", codeLines, cfg.MinReviewChanges)
+	}
+	return ""
+}
+
+// nonCodeOnly reports whether the PR has no file the AI would review:
+// every changed file is binary or non-code, like images and lockfiles
+func nonCodeOnly(files []models.DiffFile) bool {
+	for _, file := range files {
+		if file.Patch != "" && !ai.SkipReview(file.Filename) {
+			return false
+		}
+	}
+	return true
+}
+
+// otherLanguagesOnly reports whether REVIEW_LANGUAGES leaves none of the
+// PR's reviewable files for the AI
+func otherLanguagesOnly(cfg *config.Config, files []models.DiffFile) bool {
+	if len(cfg.ReviewLanguages) == 0 {
+		return false
+	}
+	for _, file := range files {
+		if !ai.SkipReview(file.Filename) && ai.InLanguages(file.Filename, cfg.ReviewLanguages) {
+			return false
+		}
+	}
+	return true
+}
+
+// trivialOnly reports whether every file the AI would review only changes
+// whitespace or comments
+func trivialOnly(cfg *config.Config, files []models.DiffFile) bool {
+	if !cfg.ReviewSkipTrivial {
+		return false
+	}
+	reviewable := false
+	for _, file := range files {
+		if file.Patch == "" || ai.SkipReview(file.Filename) || !ai.InLanguages(file.Filename, cfg.ReviewLanguages) {
+			continue
+		}
+		if !ai.TrivialChange(file.Filename, file.Patch) {
+			return false
+		}
+		reviewable = true
+	}
+	return reviewable
+}
+
+// reviewsAuthor reports whether PRs by login get an AI review. Logins are
+// compared case-insensitively.
+func reviewsAuthor(cfg *config.Config, login string) bool {
+	for _, author := range cfg.ReviewSkipAuthors {
+		if strings.EqualFold(author, login) {
+			return false
+		}
+	}
+	if len(cfg.ReviewOnlyAuthors) == 0 {
+		return true
+	}
+	for _, author := range cfg.ReviewOnlyAuthors {
+		if strings.EqualFold(author, login) {
+			return true
+		}
+	}
+	return false
+}
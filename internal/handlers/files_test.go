@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+func TestScanPRFilesCountsEveryFile(t *testing.T) {
+	t.Setenv("REVIEW_MAX_FILES", "1")
+	t.Setenv("REVIEW_SKIP_LINES", "5")
+	t.Setenv("AI_INLINE_COMMENTS", "true")
+
+	var files []models.DiffFile
+	for i := 1; i <= 3; i++ {
+		files = append(files, models.DiffFile{
+			Filename:  fmt.Sprintf("cmd/tool%d.go", i),
+			Status:    "added",
+			Additions: 2,
+			Changes:   2,
+			Patch:     "@@ -0,0 +1,2 @@\n+package main\n+var debug = false\n",
+		})
+	}
+	h := newTestHandler(t, &fakeGitClient{files: files}, &fakeNotifier{})
+	cfg := h.currentConfig()
+
+	result, got, err := h.scanPRFiles(context.Background(), cfg, "octo-org", "app", models.PullRequest{Number: 7}, nil)
+	if err != nil {
+		t.Fatalf("scanPRFiles: %v", err)
+	}
+	if result.TotalFiles != 3 || len(got.review) != 1 || got.unreviewed() != 2 {
+		t.Errorf("scanned %d file(s), kept %d for review and left out %d, want 3, 1 and 2", result.TotalFiles, len(got.review), got.unreviewed())
+	}
+	// The size limit sees all 6 lines, not just the reviewed file's 2
+	if reason := reviewSkipReason(cfg, got.lines); reason == "" {
+		t.Errorf("review of %d changed lines not skipped at REVIEW_SKIP_LINES=5", got.lines)
+	}
+	if !got.newLines["cmd/tool3.go"][2] {
+		t.Error("inline comment placement misses line 2 of cmd/tool3.go")
+	}
+}
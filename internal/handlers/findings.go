@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"context"
+	"log"
+
+	"github.com/Rishav176/GitReviewed/internal/activity"
+	"github.com/Rishav176/GitReviewed/internal/audit"
+	"github.com/Rishav176/GitReviewed/internal/findings"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// SetFindingsLogger sets where each processed PR's findings are recorded;
+// nil disables the record
+func (h *WebhookHandler) SetFindingsLogger(logger findings.Logger) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.findingsLog = logger
+}
+
+// recordFindings writes a processed PR's findings and outcome, taken from
+// its activity event, to the findings log if one is configured
+func (h *WebhookHandler) recordFindings(ctx context.Context, deliveryID string, payload models.WebhookPayload, summary prSummary, event activity.Event) {
+	h.mu.RLock()
+	logger := h.findingsLog
+	h.mu.RUnlock()
+	if logger == nil {
+		return
+	}
+
+	record := findings.Record{
+		Time:       event.Time.UTC(),
+		DeliveryID: deliveryID,
+		Action:     payload.Action,
+		Repository: payload.Repository.FullName,
+		PRNumber:   payload.PullRequest.Number,
+		SHA:        payload.PullRequest.Head.SHA,
+		Outcome:    event.Outcome,
+		Error:      event.Error,
+		Findings:   summary.Findings,
+	}
+	if record.Findings == nil {
+		record.Findings = []audit.Finding{}
+	}
+	if err := logger.Record(ctx, record); err != nil {
+		log.Printf("🚨 Failed to write findings record for %s#%d: %v", record.Repository, record.PRNumber, err)
+	}
+}
@@ -0,0 +1,87 @@
+package handlers
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/git"
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/scanner"
+)
+
+// generatedEntry is a repository's cached .gitattributes
+type generatedEntry struct {
+	attributes *git.Attributes
+	loadedAt   time.Time
+}
+
+// generatedCache holds each repository's .gitattributes. The zero value
+// is ready to use.
+type generatedCache struct {
+	mu      sync.Mutex
+	entries map[string]generatedEntry
+}
+
+// generatedFiles returns which files of owner/repo are marked
+// linguist-generated, or nil when generated files get no special
+// treatment. The .gitattributes on the default branch is cached for
+// cfg.GeneratedFilesTTL; a repository without one marks no files.
+func (h *WebhookHandler) generatedFiles(ctx context.Context, cfg *config.Config, owner, repo string) *git.Attributes {
+	if !cfg.SkipGeneratedFiles && cfg.GeneratedDowngrade == 0 {
+		return nil
+	}
+
+	key := owner + "/" + repo
+	h.generated.mu.Lock()
+	defer h.generated.mu.Unlock()
+
+	if entry, ok := h.generated.entries[key]; ok && time.Since(entry.loadedAt) < cfg.GeneratedFilesTTL {
+		return entry.attributes
+	}
+
+	// An empty ref reads from the repository's default branch
+	var attributes *git.Attributes
+	content, err := h.gitClient.GetFileContent(ctx, owner, repo, git.AttributesFile, "")
+	if err != nil {
+		log.Printf("No %s read for %s, treating no files as generated: %v", git.AttributesFile, key, err)
+	} else {
+		attributes = git.ParseAttributes(content)
+	}
+
+	if h.generated.entries == nil {
+		h.generated.entries = make(map[string]generatedEntry)
+	}
+	h.generated.entries[key] = generatedEntry{attributes: attributes, loadedAt: time.Now()}
+	return attributes
+}
+
+// withoutGenerated drops the files marked as generated
+func withoutGenerated(files []models.DiffFile, attributes *git.Attributes) []models.DiffFile {
+	if attributes == nil {
+		return files
+	}
+
+	kept := make([]models.DiffFile, 0, len(files))
+	for _, file := range files {
+		if !attributes.Generated(file.Filename) {
+			kept = append(kept, file)
+		}
+	}
+	return kept
+}
+
+// downgradeGenerated lowers the severity of findings in generated files
+// by levels
+func downgradeGenerated(issues []models.SecurityIssue, attributes *git.Attributes, levels int) {
+	if attributes == nil || levels == 0 {
+		return
+	}
+	for i, issue := range issues {
+		if issue.FilePath != "" && attributes.Generated(issue.FilePath) {
+			issues[i] = scanner.AdjustSeverity(issue, -levels)
+		}
+	}
+}
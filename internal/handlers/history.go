@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/history"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// SetAuthorHistory sets where per-author findings are tracked; nil
+// disables author history
+func (h *WebhookHandler) SetAuthorHistory(store history.Store) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.authorHistory = store
+}
+
+// trackAuthor records the PR's findings against its author and returns the
+// author's earlier history, or nil when history is disabled or the PR has
+// no findings
+func (h *WebhookHandler) trackAuthor(ctx context.Context, repo models.Repository, pr models.PullRequest, result models.ScanResult) *models.AuthorHistory {
+	h.mu.RLock()
+	store := h.authorHistory
+	h.mu.RUnlock()
+	if store == nil || !result.Found || pr.User.Login == "" {
+		return nil
+	}
+
+	key := fmt.Sprintf("%s#%d", repo.FullName, pr.Number)
+	prior, err := store.Prior(ctx, pr.User.Login, key)
+	if err != nil {
+		log.Printf("Error reading author history for %s: %v", pr.User.Login, err)
+		return nil
+	}
+
+	offense := history.Offense{
+		Author:   pr.User.Login,
+		PR:       key,
+		Findings: len(result.Issues),
+		Time:     time.Now(),
+	}
+	if err := store.Record(ctx, offense); err != nil {
+		log.Printf("Error recording author history for %s: %v", pr.User.Login, err)
+	}
+
+	prs, findings := history.Summarize(prior)
+	return &models.AuthorHistory{PriorPRs: prs, PriorFindings: findings}
+}
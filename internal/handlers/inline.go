@@ -0,0 +1,101 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// inlineReviewMarker identifies the reviews carrying AI inline comments
+const inlineReviewMarker = "<!-- gitreviewed:ai-review -->"
+
+// maxInlineComments caps the inline comments posted per review; the rest
+// are listed in the review body
+const maxInlineComments = 30
+
+// postInlineReview posts the AI review's line-specific findings as inline
+// comments on the PR's diff. Findings on lines the diff doesn't show can't
+// be placed, so they are listed in the review body instead; newLines holds
+// the lines each file's diff shows, covering every file of the PR. A comment
+// already on the same line with the same text, e.g. from the review of an
+// earlier push, isn't posted again, and nothing is posted when every
+// comment is already there.
+func (h *WebhookHandler) postInlineReview(ctx context.Context, cfg *config.Config, owner, repo string, reviewCtx models.ReviewContext, review models.ReviewResult, newLines map[string]map[int]bool) {
+	if !cfg.AIInlineComments || len(review.Comments) == 0 {
+		return
+	}
+
+	pr := reviewCtx.PullRequest
+	comments := review.Comments
+	existing, err := h.gitClient.ListReviewComments(ctx, owner, repo, pr.Number)
+	if err != nil {
+		log.Printf("Error listing review comments on PR #%d, posting all AI inline comments: %v", pr.Number, err)
+	} else {
+		comments = newComments(comments, existing)
+	}
+	if len(comments) == 0 {
+		log.Printf("All AI inline comments are already on PR #%d", pr.Number)
+		return
+	}
+
+	placed, unplaced := placeComments(newLines, comments)
+	body := inlineReviewBody(models.ShortSHA(pr.Head.SHA), len(placed), unplaced)
+	if err := h.gitClient.SubmitReviewComments(ctx, owner, repo, pr.Number, pr.Head.SHA, body, placed); err != nil {
+		log.Printf("Error posting AI inline comments on PR #%d: %v", pr.Number, err)
+		return
+	}
+	log.Printf("Posted %d AI inline comment(s) on PR #%d, %d in the review body", len(placed), pr.Number, len(unplaced))
+}
+
+// newComments returns the comments that aren't already in existing on the
+// same line with the same text
+func newComments(comments, existing []models.ReviewComment) []models.ReviewComment {
+	posted := make(map[models.ReviewComment]bool, len(existing))
+	for _, comment := range existing {
+		comment.Body = strings.TrimSpace(comment.Body)
+		posted[comment] = true
+	}
+
+	var fresh []models.ReviewComment
+	for _, comment := range comments {
+		key := comment
+		key.Body = strings.TrimSpace(key.Body)
+		if !posted[key] {
+			fresh = append(fresh, comment)
+		}
+	}
+	return fresh
+}
+
+// placeComments splits comments into those on lines shown in the diff,
+// up to maxInlineComments, and the rest
+func placeComments(lines map[string]map[int]bool, comments []models.ReviewComment) ([]models.ReviewComment, []models.ReviewComment) {
+	var placed, unplaced []models.ReviewComment
+	for _, comment := range comments {
+		if lines[comment.Path][comment.Line] && len(placed) < maxInlineComments {
+			placed = append(placed, comment)
+		} else {
+			unplaced = append(unplaced, comment)
+		}
+	}
+	return placed, unplaced
+}
+
+// inlineReviewBody summarizes an inline review and lists the comments that
+// couldn't be placed on the diff
+func inlineReviewBody(sha string, placed int, unplaced []models.ReviewComment) string {
+	var b strings.Builder
+	b.WriteString(inlineReviewMarker + "\n")
+	b.WriteString(fmt.Sprintf("🤖 GitReviewed AI review of commit `%s`: %d inline comment(s).", sha, placed))
+	if len(unplaced) > 0 {
+		b.WriteString("\n\nThese comments refer to lines outside the diff:\n\n")
+		for _, comment := range unplaced {
+			b.WriteString(fmt.Sprintf("- `%s` line %d: %s\n", comment.Path, comment.Line, comment.Body))
+		}
+	}
+	return b.String()
+}
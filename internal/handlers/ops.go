@@ -0,0 +1,11 @@
+package handlers
+
+import "log"
+
+// reportFailure posts a processing failure to the ops channel, if one is
+// configured, so operators don't have to watch the logs to notice it
+func (h *WebhookHandler) reportFailure(subject string, err error) {
+	if err := h.notifier.SendOpsAlert(subject, err.Error()); err != nil {
+		log.Printf("Error sending ops alert: %v", err)
+	}
+}
@@ -0,0 +1,145 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/scanner"
+)
+
+// maxPatternRequestBytes limits the body accepted by POST /patterns
+const maxPatternRequestBytes = 64 << 10
+
+// patternInfo describes a pattern in the GET /patterns response
+type patternInfo struct {
+	Name        string `json:"name"`
+	Severity    string `json:"severity"`
+	Regex       string `json:"regex"`
+	Description string `json:"description"`
+	Generic     bool   `json:"generic,omitempty"`
+	MinLength   int    `json:"min_length,omitempty"`
+	Multiline   bool   `json:"multiline,omitempty"`
+}
+
+// patternRequest is the body of POST /patterns: either a pattern to add,
+// or the name of a pattern to disable
+type patternRequest struct {
+	Action  string               `json:"action"`
+	Pattern scanner.PatternEntry `json:"pattern"`
+	Name    string               `json:"name"`
+}
+
+// Patterns handles GET /patterns, listing the patterns the scanner is
+// using, and POST /patterns, adding or disabling a pattern in the pattern
+// file and applying the result to the running scanner
+func (h *WebhookHandler) Patterns(w http.ResponseWriter, r *http.Request) {
+	if !h.authorized(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		h.listPatterns(w)
+	case http.MethodPost:
+		h.editPatterns(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+// listPatterns writes the scanner's current patterns
+func (h *WebhookHandler) listPatterns(w http.ResponseWriter) {
+	patterns := h.secretScanner.Patterns()
+	infos := make([]patternInfo, 0, len(patterns))
+	for _, p := range patterns {
+		infos = append(infos, patternInfo{
+			Name:        p.Name,
+			Severity:    p.Severity,
+			Regex:       p.Pattern.String(),
+			Description: p.Description,
+			Generic:     p.Generic,
+			MinLength:   p.MinLength,
+			Multiline:   p.Multiline,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"patterns": infos,
+	})
+}
+
+// editPatterns applies a POST /patterns request. The pattern file is
+// validated before it's replaced, so a rejected request changes nothing.
+func (h *WebhookHandler) editPatterns(w http.ResponseWriter, r *http.Request) {
+	var req patternRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxPatternRequestBytes)).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("Invalid request: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	// Edits and reloads both read the file and swap the patterns, so
+	// they take turns
+	h.patternEdits.Lock()
+	defer h.patternEdits.Unlock()
+
+	cfg := h.currentConfig()
+	if cfg.PatternsFile == "" {
+		http.Error(w, "PATTERNS_FILE is not set, so changes can't be saved", http.StatusConflict)
+		return
+	}
+
+	var change string
+	switch req.Action {
+	case "add":
+		if err := scanner.AddPatternToFile(cfg.PatternsFile, req.Pattern); err != nil {
+			http.Error(w, fmt.Sprintf("Invalid pattern: %v", err), http.StatusBadRequest)
+			return
+		}
+		change = fmt.Sprintf("added %q", req.Pattern.Name)
+	case "disable":
+		if !hasPattern(h.secretScanner.Patterns(), req.Name) {
+			http.Error(w, fmt.Sprintf("No active pattern is named %q", req.Name), http.StatusNotFound)
+			return
+		}
+		if err := scanner.DisablePatternInFile(cfg.PatternsFile, req.Name); err != nil {
+			http.Error(w, fmt.Sprintf("Failed to disable pattern: %v", err), http.StatusInternalServerError)
+			return
+		}
+		change = fmt.Sprintf("disabled %q", req.Name)
+	default:
+		http.Error(w, `action must be "add" or "disable"`, http.StatusBadRequest)
+		return
+	}
+
+	patterns, err := loadPatterns(cfg)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Saved, but failed to load patterns: %v", err), http.StatusInternalServerError)
+		return
+	}
+	changes := diffPatterns(h.secretScanner.Patterns(), patterns)
+	h.secretScanner.SetPatterns(patterns)
+
+	log.Printf("Patterns updated via API: %s", change)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"patterns": len(patterns),
+		"changes":  changes,
+	})
+}
+
+// hasPattern reports whether patterns include one named name, ignoring
+// case like DISABLED_PATTERNS
+func hasPattern(patterns []scanner.SecretPattern, name string) bool {
+	for _, p := range patterns {
+		if strings.EqualFold(p.Name, name) {
+			return true
+		}
+	}
+	return false
+}
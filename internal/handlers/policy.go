@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/git"
+	"github.com/Rishav176/GitReviewed/internal/policy"
+)
+
+// newPolicyCache creates a cache for the organization policy configured in
+// cfg, or nil when no policy is configured
+func newPolicyCache(cfg *config.Config, gitClient git.Client) *policy.Cache {
+	switch {
+	case cfg.OrgPolicyFile != "":
+		path := cfg.OrgPolicyFile
+		return policy.NewCache(func(ctx context.Context) ([]byte, error) {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read org policy: %w", err)
+			}
+			return data, nil
+		}, cfg.OrgPolicyTTL)
+
+	case cfg.OrgPolicyRepo != "":
+		owner, repo, _ := strings.Cut(cfg.OrgPolicyRepo, "/")
+		path := cfg.OrgPolicyPath
+		return policy.NewCache(func(ctx context.Context) ([]byte, error) {
+			// An empty ref reads from the repository's default branch
+			content, err := gitClient.GetFileContent(ctx, owner, repo, path, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to fetch org policy: %w", err)
+			}
+			return []byte(content), nil
+		}, cfg.OrgPolicyTTL)
+	}
+
+	return nil
+}
+
+// blockThreshold returns the severity that blocks a merge in repo. The
+// repository's own block_severity wins over the organization policy's,
+// which wins over BLOCK_SEVERITY.
+func blockThreshold(cfg *config.Config, repo string, orgPolicy *policy.Policy) string {
+	if severity := cfg.RepoSettings(repo).BlockSeverity; severity != "" {
+		return severity
+	}
+	return orgPolicy.BlockThreshold(cfg.BlockSeverity)
+}
+
+// currentPolicy returns the organization policy, reloading it if its TTL
+// has expired. It returns nil when no policy is configured.
+func (h *WebhookHandler) currentPolicy(ctx context.Context) *policy.Policy {
+	h.mu.RLock()
+	cache := h.orgPolicy
+	h.mu.RUnlock()
+	return cache.Get(ctx)
+}
@@ -0,0 +1,81 @@
+package handlers
+
+import (
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/scanner"
+)
+
+// tagProjects sets the monorepo sub-project of each file finding
+func tagProjects(cfg *config.Config, issues []models.SecurityIssue) {
+	for i, issue := range issues {
+		if issue.FilePath == "" {
+			continue
+		}
+		if project := cfg.ProjectFor(issue.FilePath); project != nil {
+			issues[i].Project = project.Name
+		}
+	}
+}
+
+// splitByProject splits result into one result per sub-project with its
+// own Slack channel, so each project's findings reach its owners. Other
+// findings stay together in a result routed as usual. Without projects,
+// result is returned unchanged.
+func splitByProject(cfg *config.Config, result models.ScanResult) []models.ScanResult {
+	if len(cfg.Projects) == 0 {
+		return []models.ScanResult{result}
+	}
+
+	routed := make(map[string]bool, len(cfg.Projects))
+	for _, project := range cfg.Projects {
+		routed[project.Name] = project.SlackChannel != ""
+	}
+
+	var order []string
+	groups := make(map[string][]models.SecurityIssue)
+	for _, issue := range result.Issues {
+		key := ""
+		if routed[issue.Project] {
+			key = issue.Project
+		}
+		if _, ok := groups[key]; !ok {
+			order = append(order, key)
+		}
+		groups[key] = append(groups[key], issue)
+	}
+
+	results := make([]models.ScanResult, 0, len(order))
+	for _, key := range order {
+		group := result
+		group.Issues = groups[key]
+		group.Found = len(group.Issues) > 0
+		results = append(results, group)
+	}
+	return results
+}
+
+// projectBlockingIssues returns the issues at or above the block threshold
+// of their sub-project, or threshold when the project doesn't set one
+func projectBlockingIssues(cfg *config.Config, threshold string, issues []models.SecurityIssue) []models.SecurityIssue {
+	if len(cfg.Projects) == 0 {
+		return blockingIssues(threshold, issues)
+	}
+
+	thresholds := make(map[string]string, len(cfg.Projects))
+	for _, project := range cfg.Projects {
+		thresholds[project.Name] = project.BlockSeverity
+	}
+
+	var blocking []models.SecurityIssue
+	for _, issue := range issues {
+		limit := threshold
+		if t := thresholds[issue.Project]; issue.Project != "" && t != "" {
+			limit = t
+		}
+		if scanner.SeverityRank(issue.Severity) >= scanner.SeverityRank(limit) {
+			blocking = append(blocking, issue)
+		}
+	}
+	return blocking
+}
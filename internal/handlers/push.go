@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/scanner"
+)
+
+// zeroSHA is the "before" SHA GitHub sends when a branch is created
+const zeroSHA = "0000000000000000000000000000000000000000"
+
+// fingerprintMarker prefixes the hidden per-finding markers in remediation
+// issues, used to avoid opening duplicate issues on repeated pushes
+const fingerprintMarker = "<!-- gitreviewed:fingerprint:"
+
+// handlePushEvent starts secret scanning for a push to a watched branch
+func (h *WebhookHandler) handlePushEvent(w http.ResponseWriter, body []byte, deliveryID string) {
+	var payload models.PushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		log.Printf("Error parsing push payload: %v", err)
+		http.Error(w, "Bad request", http.StatusBadRequest)
+		return
+	}
+
+	branch := payload.Branch()
+	if payload.Deleted || branch == "" || !watchesBranch(h.currentConfig().PushBranches, branch) {
+		log.Printf("Ignoring push to %s", payload.Ref)
+		h.recordIgnored(deliveryID, "push", "", payload.Repository.FullName)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Push ignored"))
+		return
+	}
+
+	go h.trackPush(deliveryID, payload)
+
+	w.WriteHeader(http.StatusOK)
+	w.Write([]byte("Webhook received"))
+}
+
+// processPush scans the changes in a push and alerts Slack on findings.
+// There is no PR to block, so findings are only reported.
+func (h *WebhookHandler) processPush(payload models.PushPayload) (models.ScanResult, error) {
+	ctx := context.Background()
+	cfg := h.currentConfig().ForRepo(payload.Repository.FullName)
+
+	owner := payload.Repository.Owner.Login
+	repo := payload.Repository.Name
+
+	// A new branch has no previous commit, so compare against the default branch
+	base := payload.Before
+	if base == zeroSHA || base == "" {
+		base = payload.Repository.DefaultBranch
+	}
+
+	log.Printf("Processing push to %s/%s@%s (%s...%s)", owner, repo, payload.Branch(), base, payload.After)
+
+	diffFiles, err := h.gitClient.GetCompareDiff(ctx, owner, repo, base, payload.After)
+	if err != nil {
+		log.Printf("Error fetching push diff: %v", err)
+		h.reportFailure(fmt.Sprintf("Failed to fetch the diff of a push to %s/%s@%s", owner, repo, payload.Branch()), err)
+		return models.ScanResult{}, fmt.Errorf("failed to fetch push diff: %w", err)
+	}
+
+	scanFiles, unscanned := h.resolveMissingPatches(ctx, owner, repo, payload.After, diffFiles, cfg.ScanRenamedFiles)
+	scanResult := h.secretScanner.ScanFiles(scanFiles)
+	scanResult.ScannedAt = time.Now()
+	scanResult.UnscannedFiles = unscanned
+
+	scanResult = withoutRepoPatterns(cfg, payload.Repository.FullName, scanResult)
+	orgPolicy := h.currentPolicy(ctx)
+	scanResult = orgPolicy.Apply(scanResult)
+	scanResult.Issues = scanner.AdjustSeverities(scanResult.Issues, severityRules(cfg))
+	tagProjects(cfg, scanResult.Issues)
+
+	log.Printf("Push scan complete: found %d issues in %d files", len(scanResult.Issues), len(diffFiles))
+
+	if scanResult.Found {
+		for _, result := range splitByProject(cfg, scanResult) {
+			if err := h.notifier.SendPushAlert(payload, result); err != nil {
+				log.Printf("Error sending push alert to Slack: %v", err)
+				h.reportFailure(fmt.Sprintf("Failed to send the push alert for %s/%s@%s", owner, repo, payload.Branch()), err)
+			}
+		}
+	}
+
+	if blocking := projectBlockingIssues(cfg, blockThreshold(cfg, payload.Repository.FullName, orgPolicy), scanResult.Issues); cfg.PushIssues && len(blocking) > 0 {
+		h.openRemediationIssue(ctx, cfg.PushIssueLabels, payload, blocking)
+	}
+
+	return scanResult, nil
+}
+
+// openRemediationIssue opens an issue tracking the rotation of secrets that
+// landed on a branch. Findings already tracked by an open issue are skipped
+// so repeated pushes don't create duplicates.
+func (h *WebhookHandler) openRemediationIssue(ctx context.Context, labels []string, payload models.PushPayload, issues []models.SecurityIssue) {
+	owner := payload.Repository.Owner.Login
+	repo := payload.Repository.Name
+
+	existing, err := h.gitClient.ListOpenIssues(ctx, owner, repo, labels)
+	if err != nil {
+		log.Printf("Error listing open issues: %v", err)
+		return
+	}
+
+	var untracked []models.SecurityIssue
+	for _, issue := range issues {
+		marker := fingerprintMarker + issue.Fingerprint + " -->"
+		tracked := false
+		for _, open := range existing {
+			if strings.Contains(open.Body, marker) {
+				tracked = true
+				break
+			}
+		}
+		if !tracked {
+			untracked = append(untracked, issue)
+		}
+	}
+
+	if len(untracked) == 0 {
+		log.Printf("All findings already tracked by open issues")
+		return
+	}
+
+	title := fmt.Sprintf("Secrets pushed to %s: rotate %d credential(s)", payload.Branch(), len(untracked))
+	url, err := h.gitClient.CreateIssue(ctx, owner, repo, title, buildRemediationIssue(payload, untracked), labels)
+	if err != nil {
+		log.Printf("Error creating remediation issue: %v", err)
+		return
+	}
+
+	log.Printf("Opened remediation issue: %s", url)
+}
+
+// buildRemediationIssue renders the body of a remediation issue
+func buildRemediationIssue(payload models.PushPayload, issues []models.SecurityIssue) string {
+	var b strings.Builder
+
+	b.WriteString(fmt.Sprintf("GitReviewed found %d secret(s) pushed to `%s` by @%s in [%s...%s](%s).\n\n",
+		len(issues), payload.Branch(), payload.Sender.Login,
+		models.ShortSHA(payload.Before), models.ShortSHA(payload.After), payload.Compare))
+	b.WriteString("These secrets are already on the branch. Removing them in a later commit is not enough: they must be rotated.\n\n")
+
+	b.WriteString("| Severity | Type | File | Line |\n")
+	b.WriteString("|---|---|---|---|\n")
+	for _, issue := range issues {
+		b.WriteString(fmt.Sprintf("| %s | %s | `%s` | %s |\n", issue.Severity, issue.Type, issue.FilePath, issue.Line()))
+	}
+
+	b.WriteString("\n### Remediation checklist\n\n")
+	for _, issue := range issues {
+		b.WriteString(fmt.Sprintf("- [ ] Rotate the %s in `%s`\n", issue.Type, issue.FilePath))
+	}
+	b.WriteString("- [ ] Check the provider's audit logs for use of the leaked credentials\n")
+	b.WriteString("- [ ] Remove the secrets from the code and load them from a secret store\n")
+	b.WriteString("- [ ] Decide whether the branch history needs rewriting\n\n")
+
+	for _, issue := range issues {
+		b.WriteString(fingerprintMarker + issue.Fingerprint + " -->\n")
+	}
+
+	return b.String()
+}
+
+// watchesBranch reports whether pushes to branch should be scanned. A "*"
+// entry matches every branch.
+func watchesBranch(branches []string, branch string) bool {
+	for _, b := range branches {
+		if b == "*" || strings.EqualFold(b, branch) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// readyCheckTimeout bounds each dependency check
+const readyCheckTimeout = 10 * time.Second
+
+// readyCacheTTL keeps probes from calling paid APIs on every request
+const readyCacheTTL = 30 * time.Second
+
+// dependencyStatus is the readiness result for a single dependency
+type dependencyStatus struct {
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
+
+// readyResponse is the JSON body returned by Ready
+type readyResponse struct {
+	Status       string                      `json:"status"`
+	Dependencies map[string]dependencyStatus `json:"dependencies"`
+	CheckedAt    time.Time                   `json:"checked_at"`
+}
+
+// readyCache holds the last readiness result
+type readyCache struct {
+	mu       sync.Mutex
+	response *readyResponse
+}
+
+// Ready checks that GitHub, Slack and the AI backend are reachable and
+// returns 503 if any of them is not. Use /health for liveness probes;
+// results here are cached briefly because the AI check costs a request.
+func (h *WebhookHandler) Ready(w http.ResponseWriter, r *http.Request) {
+	h.ready.mu.Lock()
+	resp := h.ready.response
+	if resp == nil || time.Since(resp.CheckedAt) > readyCacheTTL {
+		resp = h.checkDependencies(r.Context())
+		h.ready.response = resp
+	}
+	h.ready.mu.Unlock()
+
+	code := http.StatusOK
+	if resp.Status != "ok" {
+		code = http.StatusServiceUnavailable
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(resp)
+}
+
+// checkDependencies runs all dependency checks concurrently
+func (h *WebhookHandler) checkDependencies(ctx context.Context) *readyResponse {
+	ctx, cancel := context.WithTimeout(ctx, readyCheckTimeout)
+	defer cancel()
+
+	checks := map[string]func() error{
+		"github": func() error { return h.gitClient.Ping(ctx) },
+		"slack":  h.notifier.TestConnection,
+	}
+	if h.currentConfig().AIReviewUsed() {
+		checks["ai"] = func() error {
+			if h.reviewer == nil {
+				return errAIUnavailable
+			}
+			return h.reviewer.TestConnection()
+		}
+	}
+
+	resp := &readyResponse{
+		Status:       "ok",
+		Dependencies: make(map[string]dependencyStatus, len(checks)),
+		CheckedAt:    time.Now(),
+	}
+
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	for name, check := range checks {
+		wg.Add(1)
+		go func(name string, check func() error) {
+			defer wg.Done()
+			err := runCheck(ctx, check)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				resp.Status = "unavailable"
+				resp.Dependencies[name] = dependencyStatus{Status: "error", Error: err.Error()}
+				return
+			}
+			resp.Dependencies[name] = dependencyStatus{Status: "ok"}
+		}(name, check)
+	}
+	wg.Wait()
+
+	return resp
+}
+
+// runCheck runs check, giving up when ctx is done. Checks that don't take
+// a context keep running in the background until they return.
+func runCheck(ctx context.Context, check func() error) error {
+	done := make(chan error, 1)
+	go func() { done <- check() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return fmt.Errorf("check timed out: %w", ctx.Err())
+	}
+}
+
+// CheckGitHubAccess verifies at startup that the GitHub token can read
+// pull requests and post commit statuses in the configured repos
+func (h *WebhookHandler) CheckGitHubAccess(ctx context.Context) error {
+	return h.gitClient.CheckAccess(ctx, h.currentConfig().GitHubAccessCheckRepos)
+}
@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/git"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// reviewMarker identifies the formal reviews GitReviewed submits so they
+// can be dismissed once the PR is no longer blocked
+const reviewMarker = "<!-- gitreviewed:review -->"
+
+// submitReview submits a formal PR review reflecting the scan: changes are
+// requested when blocking issues were found, otherwise earlier change
+// requests are dismissed and the configured clean event is used. A review
+// is only submitted when the blocking state changes, so pushes that keep
+// the PR blocked, or clean, don't pile up reviews.
+func (h *WebhookHandler) submitReview(ctx context.Context, cfg *config.Config, owner, repo string, reviewCtx models.ReviewContext, blocking []models.SecurityIssue) {
+	if !cfg.PRReview {
+		return
+	}
+
+	event, body := reviewEvent(cfg.PRReviewCleanEvent, reviewCtx, blocking)
+	prNumber := reviewCtx.PullRequest.Number
+
+	// A follow-up commit removed the blocking secrets, so the old change
+	// requests no longer apply
+	if len(blocking) == 0 {
+		message := fmt.Sprintf("Blocking secrets were removed in commit %s", models.ShortSHA(reviewCtx.PullRequest.Head.SHA))
+		dismissed, err := h.gitClient.DismissReviews(ctx, owner, repo, prNumber, reviewMarker, message)
+		if err != nil {
+			log.Printf("Error dismissing earlier reviews on PR #%d: %v", prNumber, err)
+		} else if dismissed > 0 {
+			log.Printf("Dismissed %d earlier change request(s) on PR #%d", dismissed, prNumber)
+		}
+	}
+
+	if event == "" {
+		return
+	}
+	last, err := h.gitClient.LastReview(ctx, owner, repo, prNumber, reviewMarker)
+	if err != nil {
+		log.Printf("Error looking up the last review on PR #%d: %v", prNumber, err)
+	} else if last != nil && last.Event == event {
+		log.Printf("PR #%d already has a %s review from commit %s, not submitting another", prNumber, event, models.ShortSHA(last.CommitSHA))
+		return
+	}
+	if err := h.gitClient.SubmitReview(ctx, owner, repo, prNumber, event, reviewMarker+"\n"+body); err != nil {
+		log.Printf("Error submitting %s review on PR #%d: %v", event, prNumber, err)
+	}
+}
+
+// reviewEvent picks the review event and body for a scanned PR. It returns
+// an empty event when no review should be submitted.
+func reviewEvent(cleanEvent string, reviewCtx models.ReviewContext, blocking []models.SecurityIssue) (string, string) {
+	sha := models.ShortSHA(reviewCtx.PullRequest.Head.SHA)
+
+	if len(blocking) > 0 {
+		var b strings.Builder
+		b.WriteString(fmt.Sprintf("🚨 GitReviewed found %d blocking secret(s) in commit `%s`. Remove and rotate them before merging:\n\n", len(blocking), sha))
+		for _, issue := range blocking {
+			location := fmt.Sprintf("`%s` line %s", issue.FilePath, issue.Line())
+			if issue.CommitSHA != "" {
+				location = "commit message " + models.ShortSHA(issue.CommitSHA)
+			}
+			b.WriteString(fmt.Sprintf("- **%s** %s in %s\n", issue.Severity, issue.Type, location))
+		}
+		return git.ReviewRequestChanges, b.String()
+	}
+
+	body := fmt.Sprintf("✅ GitReviewed found no blocking secrets in commit `%s`.", sha)
+	if n := len(reviewCtx.ScanResult.Issues); n > 0 {
+		body = fmt.Sprintf("⚠️ GitReviewed found %d non-blocking issue(s) in commit `%s`. Review recommended.", n, sha)
+		// Don't approve a PR that still has findings
+		if cleanEvent == "approve" {
+			cleanEvent = "comment"
+		}
+	}
+
+	switch cleanEvent {
+	case "approve":
+		return git.ReviewApprove, body
+	case "comment":
+		return git.ReviewComment, body
+	}
+	return "", ""
+}
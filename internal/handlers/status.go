@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/scanner"
+)
+
+// StatusPolicy decides the commit status state and description for a
+// PR's scan result
+type StatusPolicy func(result models.ScanResult) (state, description string)
+
+// statusTargetURL returns the link behind a PR's commit statuses: the
+// STATUS_TARGET_URL template filled in for the PR, or the PR itself
+func statusTargetURL(cfg *config.Config, owner, repo string, pr models.PullRequest) string {
+	if cfg.StatusTargetURL == "" {
+		return pr.HTMLURL
+	}
+	return config.ExpandStatusTargetURL(cfg.StatusTargetURL, map[string]string{
+		"owner":  url.PathEscape(owner),
+		"repo":   url.PathEscape(repo),
+		"number": strconv.Itoa(pr.Number),
+		"sha":    pr.Head.SHA,
+	})
+}
+
+// statusStateRank orders commit states from least to most restrictive
+var statusStateRank = map[string]int{
+	"success": 1,
+	"pending": 2,
+	"failure": 3,
+}
+
+// statusPolicy returns the policy selected by cfg: per-severity states
+// when STATUS_STATES is set, otherwise failure at or above threshold.
+// Either way a finding at or above threshold, or its sub-project's
+// threshold, fails the status.
+func statusPolicy(cfg *config.Config, threshold string) StatusPolicy {
+	if len(cfg.StatusStates) > 0 {
+		return severityStatePolicy(cfg, threshold, cfg.StatusStates)
+	}
+	return thresholdPolicy(cfg, threshold)
+}
+
+// thresholdPolicy fails the status when any finding is at or above
+// threshold, or its sub-project's threshold, and passes it otherwise,
+// noting non-blocking findings
+func thresholdPolicy(cfg *config.Config, threshold string) StatusPolicy {
+	return func(result models.ScanResult) (string, string) {
+		if n := len(projectBlockingIssues(cfg, threshold, result.Issues)); n > 0 {
+			return "failure", fmt.Sprintf("❌ Found %d blocking secret(s) - merge blocked!", n)
+		}
+		if result.Found {
+			return "success", fmt.Sprintf("⚠️  Found %d non-blocking issue(s) - review recommended", len(result.Issues))
+		}
+		return "success", "✅ No secrets detected - safe to merge"
+	}
+}
+
+// severityStatePolicy maps each finding's severity to a state and uses the
+// most restrictive one. Findings that thresholdPolicy would block fail
+// whatever their severity's state; other severities without a state count
+// as success.
+func severityStatePolicy(cfg *config.Config, threshold string, states map[string]string) StatusPolicy {
+	issueState := func(issue models.SecurityIssue) string {
+		if len(projectBlockingIssues(cfg, threshold, []models.SecurityIssue{issue})) > 0 {
+			return "failure"
+		}
+		if s := states[issue.Severity]; s != "" {
+			return s
+		}
+		return "success"
+	}
+
+	return func(result models.ScanResult) (string, string) {
+		state := "success"
+		for _, issue := range result.Issues {
+			if s := issueState(issue); statusStateRank[s] > statusStateRank[state] {
+				state = s
+			}
+		}
+
+		// Name the most severe finding responsible for the state
+		worst := ""
+		for _, issue := range result.Issues {
+			if issueState(issue) == state && scanner.SeverityRank(issue.Severity) > scanner.SeverityRank(worst) {
+				worst = issue.Severity
+			}
+		}
+
+		switch state {
+		case "failure":
+			return state, fmt.Sprintf("❌ Found %d issue(s), worst %s - merge blocked!", len(result.Issues), worst)
+		case "pending":
+			return state, fmt.Sprintf("⏸️  Found %d issue(s), worst %s - needs review before merging", len(result.Issues), worst)
+		}
+		if result.Found {
+			return state, fmt.Sprintf("⚠️  Found %d non-blocking issue(s) - review recommended", len(result.Issues))
+		}
+		return state, "✅ No secrets detected - safe to merge"
+	}
+}
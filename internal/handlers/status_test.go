@@ -0,0 +1,38 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+func TestStatusPolicyStates(t *testing.T) {
+	cfg := &config.Config{
+		StatusStates: map[string]string{"HIGH": "pending", "MEDIUM": "pending"},
+		Projects:     []config.Project{{Name: "payments", BlockSeverity: "MEDIUM"}},
+	}
+
+	tests := []struct {
+		name      string
+		threshold string
+		issues    []models.SecurityIssue
+		want      string
+	}{
+		{"no findings", "CRITICAL", nil, "success"},
+		{"listed below threshold", "CRITICAL", []models.SecurityIssue{{Severity: "HIGH"}}, "pending"},
+		{"unlisted below threshold", "CRITICAL", []models.SecurityIssue{{Severity: "LOW"}}, "success"},
+		{"unlisted at threshold", "CRITICAL", []models.SecurityIssue{{Severity: "CRITICAL"}}, "failure"},
+		{"listed at threshold", "HIGH", []models.SecurityIssue{{Severity: "HIGH"}}, "failure"},
+		{"project threshold", "CRITICAL", []models.SecurityIssue{{Severity: "MEDIUM", Project: "payments"}}, "failure"},
+		{"most restrictive wins", "CRITICAL", []models.SecurityIssue{{Severity: "LOW"}, {Severity: "MEDIUM"}, {Severity: "CRITICAL"}}, "failure"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := models.ScanResult{Found: len(tt.issues) > 0, Issues: tt.issues}
+			if state, description := statusPolicy(cfg, tt.threshold)(result); state != tt.want {
+				t.Errorf("state = %q (%s), want %q", state, description, tt.want)
+			}
+		})
+	}
+}
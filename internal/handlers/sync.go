@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"context"
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/Rishav176/GitReviewed/internal/audit"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// prSummary is the outcome of processing a PR, returned by webhooks in
+// sync mode. It never contains secret values.
+type prSummary struct {
+	Repository string          `json:"repository"`
+	PRNumber   int             `json:"pr_number"`
+	SHA        string          `json:"sha"`
+	State      string          `json:"state"`
+	Blocking   int             `json:"blocking"`
+	Findings   []audit.Finding `json:"findings"`
+	AIReviewed int             `json:"ai_files_reviewed"`
+	AIError    string          `json:"ai_error,omitempty"`
+}
+
+// newPRSummary builds the summary of a processed PR
+func newPRSummary(reviewCtx models.ReviewContext, blocking []models.SecurityIssue, review models.ReviewResult, aiErr error) prSummary {
+	summary := prSummary{
+		Repository: reviewCtx.Repository.FullName,
+		PRNumber:   reviewCtx.PullRequest.Number,
+		SHA:        reviewCtx.PullRequest.Head.SHA,
+		State:      "success",
+		Blocking:   len(blocking),
+		Findings:   audit.Findings(reviewCtx.ScanResult.Issues),
+		AIReviewed: review.Reviewed,
+	}
+	if len(blocking) > 0 {
+		summary.State = "failure"
+	}
+	if summary.Findings == nil {
+		summary.Findings = []audit.Finding{}
+	}
+	if aiErr != nil {
+		summary.AIError = aiErr.Error()
+	}
+	return summary
+}
+
+// processPullRequestSync processes a PR before responding, writing the
+// summary as JSON. If processing takes longer than the sync timeout the
+// request fails with 504 and processing finishes in the background.
+func (h *WebhookHandler) processPullRequestSync(w http.ResponseWriter, deliveryID string, payload models.WebhookPayload) {
+	ctx, cancel := context.WithTimeout(context.Background(), h.currentConfig().SyncTimeout)
+	defer cancel()
+
+	type outcome struct {
+		summary prSummary
+		err     error
+	}
+	done := make(chan outcome, 1)
+	go func() {
+		// Processing isn't tied to the request, so a timeout doesn't
+		// leave the PR with a pending status
+		summary, err := h.trackPullRequest(context.Background(), deliveryID, payload)
+		done <- outcome{summary, err}
+	}()
+
+	select {
+	case <-ctx.Done():
+		log.Printf("Sync processing of PR #%d timed out, continuing in background", payload.PullRequest.Number)
+		http.Error(w, "Processing timed out", http.StatusGatewayTimeout)
+	case result := <-done:
+		if result.err != nil {
+			http.Error(w, result.err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(result.summary)
+	}
+}
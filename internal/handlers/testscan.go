@@ -0,0 +1,63 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Rishav176/GitReviewed/internal/scanner"
+)
+
+// maxTestScanBytes limits the text accepted by /test-scan
+const maxTestScanBytes = 1 << 20
+
+// testScanFilename is the synthetic filename pasted text is scanned as
+const testScanFilename = "test-scan.txt"
+
+// testScanFinding is a single match reported by /test-scan
+type testScanFinding struct {
+	Pattern     string `json:"pattern"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	Line        int    `json:"line"`
+	Match       string `json:"match"` // redacted
+}
+
+// TestScan scans the request body as if it had been added to a file and
+// reports which patterns match, with the matches redacted. Lines that look
+// like examples or tests are ignored here just as in PRs.
+func (h *WebhookHandler) TestScan(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxTestScanBytes))
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to read body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	issues, values := h.secretScanner.ScanDiffWithValues(scanner.ContentAsDiff(string(body)), testScanFilename)
+
+	findings := make([]testScanFinding, 0, len(issues))
+	for _, issue := range issues {
+		findings = append(findings, testScanFinding{
+			Pattern:     issue.Pattern,
+			Severity:    issue.Severity,
+			Description: issue.Description,
+			Line:        issue.LineNumber,
+			Match:       scanner.Redact(values[issue.Fingerprint]),
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"findings": findings,
+	})
+}
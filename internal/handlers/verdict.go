@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+
+	"github.com/Rishav176/GitReviewed/internal/ai"
+	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// verdictContext is the commit status context of the combined verdict
+const verdictContext = "gitreviewed/verdict"
+
+// combinedVerdict decides the combined verdict status from the secret scan
+// status and the AI review's verdict. It fails when the scan blocks the
+// merge or, with the AI review gate, when the AI requested changes; a scan
+// waiting on review, or a gated PR without an AI verdict, stays pending.
+func combinedVerdict(scanState string, aiGate bool, aiVerdict string) (state, description string) {
+	changesRequested := aiGate && aiVerdict == ai.VerdictRequestChanges
+	switch {
+	case scanState == "failure" && changesRequested:
+		return "failure", "❌ Secret scan blocked and AI review requested changes"
+	case scanState == "failure":
+		return "failure", "❌ Secret scan blocked the merge"
+	case changesRequested:
+		return "failure", "❌ AI review requested changes"
+	case scanState == "pending":
+		return "pending", "⏸️  Secret scan findings need review before merging"
+	case aiGate && aiVerdict == "":
+		return "pending", "⏸️  No AI review verdict - needs a manual review"
+	case aiGate:
+		return "success", "✅ Secret scan and AI review passed"
+	}
+	return "success", "✅ Secret scan passed"
+}
+
+// lastVerdict is the AI verdict given for a PR's head commit
+type lastVerdict struct {
+	sha     string
+	verdict string
+}
+
+// verdictTracker remembers each PR's last AI verdict, keyed by
+// "owner/name#number", so a rescan that skips the AI review can still post
+// the combined verdict
+type verdictTracker struct {
+	mu       sync.Mutex
+	verdicts map[string]lastVerdict
+}
+
+// set records the AI verdict for sha
+func (t *verdictTracker) set(key, sha, verdict string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if t.verdicts == nil {
+		t.verdicts = make(map[string]lastVerdict)
+	}
+	t.verdicts[key] = lastVerdict{sha: sha, verdict: verdict}
+}
+
+// get returns the AI verdict recorded for sha, or "" if there is none
+func (t *verdictTracker) get(key, sha string) string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if last := t.verdicts[key]; last.sha == sha {
+		return last.verdict
+	}
+	return ""
+}
+
+// clear forgets key
+func (t *verdictTracker) clear(key string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	delete(t.verdicts, key)
+}
+
+// postVerdictStatus posts the combined verdict to each of the PR's status
+// commits. A rescan reuses the verdict of the last AI review of the same
+// commit.
+func (h *WebhookHandler) postVerdictStatus(ctx context.Context, cfg *config.Config, owner, repo string, shas []string, pr models.PullRequest, key, scanState string, review models.ReviewResult, aiErr error) {
+	if !cfg.VerdictStatus {
+		return
+	}
+
+	aiVerdict := ""
+	switch {
+	case errors.Is(aiErr, errRescanOnly):
+		aiVerdict = h.verdicts.get(key, pr.Head.SHA)
+	case aiErr == nil:
+		aiVerdict = review.Verdict
+		h.verdicts.set(key, pr.Head.SHA, aiVerdict)
+	default:
+		h.verdicts.clear(key)
+	}
+
+	state, description := combinedVerdict(scanState, cfg.AIReviewGate, aiVerdict)
+	for _, sha := range shas {
+		if sha == "" {
+			continue
+		}
+		if err := h.gitClient.PostCommitStatus(ctx, owner, repo, sha, state, description, verdictContext, statusTargetURL(cfg, owner, repo, pr)); err != nil {
+			log.Printf("Error posting verdict status to %s/%s@%s: %v", owner, repo, models.ShortSHA(sha), err)
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/Rishav176/GitReviewed/internal/ai"
+)
+
+func TestCombinedVerdict(t *testing.T) {
+	tests := []struct {
+		scanState string
+		aiGate    bool
+		aiVerdict string
+		want      string
+	}{
+		{"success", false, "", "success"},
+		{"failure", true, ai.VerdictApprove, "failure"},
+		{"success", true, ai.VerdictRequestChanges, "failure"},
+		{"pending", true, ai.VerdictApprove, "pending"},
+		{"success", true, ai.VerdictComment, "success"},
+		// A skipped or failed review leaves no verdict to pass the gate
+		{"success", true, "", "pending"},
+	}
+	for _, tt := range tests {
+		if state, _ := combinedVerdict(tt.scanState, tt.aiGate, tt.aiVerdict); state != tt.want {
+			t.Errorf("combinedVerdict(%q, %v, %q) = %q, want %q", tt.scanState, tt.aiGate, tt.aiVerdict, state, tt.want)
+		}
+	}
+}
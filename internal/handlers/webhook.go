@@ -2,40 +2,341 @@ package handlers
 
 import (
 	"context"
+	"crypto/subtle"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
+	"mime"
 	"net/http"
-	"time"
+	"strings"
+	"sync"
 
+	"github.com/Rishav176/GitReviewed/internal/activity"
 	"github.com/Rishav176/GitReviewed/internal/ai"
+	"github.com/Rishav176/GitReviewed/internal/apierr"
+	"github.com/Rishav176/GitReviewed/internal/approval"
+	"github.com/Rishav176/GitReviewed/internal/audit"
 	"github.com/Rishav176/GitReviewed/internal/config"
+	"github.com/Rishav176/GitReviewed/internal/digest"
+	"github.com/Rishav176/GitReviewed/internal/findings"
 	"github.com/Rishav176/GitReviewed/internal/git"
+	"github.com/Rishav176/GitReviewed/internal/history"
 	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/policy"
 	"github.com/Rishav176/GitReviewed/internal/scanner"
 	"github.com/Rishav176/GitReviewed/internal/slack"
+	"github.com/Rishav176/GitReviewed/internal/transport"
+	"github.com/Rishav176/GitReviewed/internal/verify"
 )
 
+// Notifier sends review results to a chat service
+type Notifier interface {
+	SendSecurityAlert(ctx models.ReviewContext) error
+	SendPushAlert(push models.PushPayload, result models.ScanResult) error
+	SendAIReview(ctx models.ReviewContext, review models.ReviewResult) error
+	SendReviewComplete(ctx models.ReviewContext) error
+	SendAIUnavailable(ctx models.ReviewContext, reason string) error
+	SendReviewSkipped(ctx models.ReviewContext, reason string) error
+	SendOpsAlert(subject, detail string) error
+	SendDigest(entries []models.DigestEntry) error
+	TestConnection() error
+}
+
+// slackOptionsSetter is implemented by notifiers whose alert style and
+// channel routes can be changed on reload
+type slackOptionsSetter interface {
+	SetOptions(opts slack.Options)
+}
+
+// githubUserAgentPrefix starts the User-Agent of genuine GitHub deliveries
+const githubUserAgentPrefix = "GitHub-Hookshot/"
+
+// errAIUnavailable is reported when no AI reviewer is configured
+var errAIUnavailable = errors.New("AI reviewer is not configured")
+
+// errReviewSkipped is reported when AI review is skipped on purpose, e.g.
+// because the PR is too large
+var errReviewSkipped = errors.New("AI review skipped")
+
+// errAIDisabled is reported for every PR when DISABLE_AI_REVIEW is set
+var errAIDisabled = fmt.Errorf("%w: AI review is disabled", errReviewSkipped)
+
 // WebhookHandler handles incoming GitHub webhooks
 type WebhookHandler struct {
+	mu            sync.RWMutex // guards config, orgPolicy, auditLog, findingsLog, authorHistory, activity and digest
 	config        *config.Config
+	orgPolicy     *policy.Cache
+	auditLog      audit.Logger
+	findingsLog   findings.Logger
+	authorHistory history.Store
+	activity      activity.Store
+	digest        digest.Store
+	blocks        blockTracker
+	approvals     approval.Store
+	verdicts      verdictTracker
+	patternEdits  sync.Mutex
+	debounce      debouncer
+	generated     generatedCache
 	gitClient     git.Client
-	slackClient   *slack.Client
+	notifier      Notifier
 	secretScanner *scanner.Scanner
-	aiClient      *ai.Client
+	reviewer      ai.Reviewer
+	ready         readyCache
+}
+
+// NewWebhookHandler creates a handler with the default GitHub, Slack,
+// scanner and Gemini implementations built from cfg
+func NewWebhookHandler(cfg *config.Config) (*WebhookHandler, error) {
+	patterns, err := loadPatterns(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	baseline, err := scanner.LoadBaseline(cfg.BaselineFile)
+	if err != nil {
+		return nil, err
+	}
+
+	secretScanner := scanner.NewScannerWithPatterns(patterns)
+	secretScanner.SetBaseline(baseline)
+	secretScanner.SetConcurrency(cfg.ScanConcurrency)
+	secretScanner.SetSensitiveFileSeverity(sensitiveFileSeverity(cfg))
+	secretScanner.SetKeyFileCheck(keyFileCheck(cfg))
+	secretScanner.SetDecodeBase64(cfg.ScanBase64)
+	if cfg.ScanSkipExtensions != nil {
+		secretScanner.SetSkipExtensions(cfg.ScanSkipExtensions)
+	}
+
+	// All outbound clients share one connection pool
+	transport := transport.New(cfg.OutboundProxy, cfg.OutboundNoProxy)
+	httpClient := &http.Client{Transport: transport, Timeout: cfg.HTTPTimeout}
+
+	if cfg.VerifySecrets {
+		secretScanner.SetVerifier(verify.NewRegistry(cfg.VerifyRequestsPerMinute, transport))
+	}
+
+	slackOpts := slackOptions(cfg)
+	slackOpts.HTTPClient = httpClient
+
+	gitClient := git.NewGitHubClient(cfg.GitHubToken, cfg.WebhookSecret, httpClient)
+	if cfg.GitHubBaseURL != "" {
+		gitClient, err = git.NewEnterpriseClient(cfg.GitHubBaseURL, cfg.GitHubToken, cfg.WebhookSecret, httpClient)
+		if err != nil {
+			return nil, err
+		}
+	}
+	h := NewWebhookHandlerWithClients(
+		cfg,
+		gitClient,
+		slack.NewClient(cfg.SlackToken, cfg.SlackChannel, slackOpts),
+		secretScanner,
+		newReviewer(cfg, &http.Client{Transport: transport, Timeout: cfg.AITimeout}),
+	)
+	h.orgPolicy = newPolicyCache(cfg, gitClient)
+
+	if h.approvals, err = newApprovalStore(cfg); err != nil {
+		return nil, err
+	}
+	if cfg.AuthorHistory {
+		h.authorHistory = history.NewMemoryStore(cfg.AuthorHistoryRetention)
+	}
+	if cfg.HistorySize > 0 {
+		h.activity = activity.NewRing(cfg.HistorySize)
+	}
+	if cfg.Digest && cfg.DigestStateFile != "" {
+		store, err := digest.NewFileStore(cfg.DigestStateFile)
+		if err != nil {
+			return nil, err
+		}
+		h.digest = store
+	} else if cfg.Digest {
+		h.digest = digest.NewMemoryStore()
+	}
+
+	if cfg.AuditLogFile != "" {
+		auditLog, err := audit.NewFileLogger(cfg.AuditLogFile)
+		if err != nil {
+			return nil, err
+		}
+		h.auditLog = auditLog
+	}
+	if cfg.FindingsFile != "" {
+		findingsLog, err := findings.NewFileLogger(cfg.FindingsFile, int64(cfg.FindingsFileMaxMB)<<20, cfg.FindingsFileBackups)
+		if err != nil {
+			return nil, err
+		}
+		h.findingsLog = findingsLog
+	}
+
+	return h, nil
+}
+
+// loadPatterns loads the pattern set from cfg without the globally disabled
+// patterns. Disabled names that match no pattern are logged as warnings.
+func loadPatterns(cfg *config.Config) ([]scanner.SecretPattern, error) {
+	all, err := scanner.LoadPatterns(cfg.PatternsFile)
+	if err != nil {
+		return nil, err
+	}
+
+	patterns, unknown := scanner.DisablePatterns(all, cfg.DisabledPatterns)
+	for _, name := range unknown {
+		if fileCheck(name) {
+			continue
+		}
+		log.Printf("⚠️  Disabled pattern %q does not match any pattern", name)
+	}
+	for repo, settings := range cfg.Repos {
+		_, unknown := scanner.DisablePatterns(all, settings.DisabledPatterns)
+		for _, name := range unknown {
+			if fileCheck(name) {
+				continue
+			}
+			log.Printf("⚠️  Disabled pattern %q for %s does not match any pattern", name, repo)
+		}
+	}
+
+	return patterns, nil
+}
+
+// fileCheck reports whether name is one of the scanner's filename checks,
+// which can be disabled like a pattern
+func fileCheck(name string) bool {
+	return strings.EqualFold(name, scanner.SensitiveFilePattern) || strings.EqualFold(name, scanner.KeyFilePattern)
+}
+
+// keyFileCheck reports whether committed key files are reported, unless
+// disabled like a pattern
+func keyFileCheck(cfg *config.Config) bool {
+	for _, name := range cfg.DisabledPatterns {
+		if strings.EqualFold(name, scanner.KeyFilePattern) {
+			return false
+		}
+	}
+	return true
 }
 
-func NewWebhookHandler(cfg *config.Config) *WebhookHandler {
+// sensitiveFileSeverity returns the severity for committed credentials
+// files, or "" when the check is turned off or disabled like a pattern
+func sensitiveFileSeverity(cfg *config.Config) string {
+	if cfg.SensitiveFileSeverity == "OFF" {
+		return ""
+	}
+	for _, name := range cfg.DisabledPatterns {
+		if strings.EqualFold(name, scanner.SensitiveFilePattern) {
+			return ""
+		}
+	}
+	return cfg.SensitiveFileSeverity
+}
+
+// severityRules converts the configured path severity rules
+func severityRules(cfg *config.Config) []scanner.SeverityRule {
+	rules := make([]scanner.SeverityRule, 0, len(cfg.SeverityRules))
+	for _, rule := range cfg.SeverityRules {
+		rules = append(rules, scanner.SeverityRule{Path: rule.Path, Adjust: rule.Adjust})
+	}
+	return rules
+}
+
+// withoutRepoPatterns drops findings from the patterns disabled for repo
+// and in the paths it ignores
+func withoutRepoPatterns(cfg *config.Config, repo string, result models.ScanResult) models.ScanResult {
+	settings := cfg.RepoSettings(repo)
+	if len(settings.DisabledPatterns) == 0 && len(settings.IgnorePaths) == 0 {
+		return result
+	}
+
+	var kept []models.SecurityIssue
+	for _, issue := range result.Issues {
+		off := issue.FilePath != "" && settings.Ignores(issue.FilePath)
+		for _, name := range settings.DisabledPatterns {
+			if strings.EqualFold(name, issue.Pattern) {
+				off = true
+				break
+			}
+		}
+		if !off {
+			kept = append(kept, issue)
+		}
+	}
+
+	result.Issues = kept
+	result.Found = len(kept) > 0
+	return result
+}
+
+// newReviewer creates the AI reviewer selected by AI_PROVIDER, or nil if
+// it could not be created
+func newReviewer(cfg *config.Config, httpClient *http.Client) ai.Reviewer {
+	if !cfg.AIReviewUsed() {
+		return nil
+	}
+
+	opts := ai.Options{
+		HTTPClient:        httpClient,
+		PromptTemplate:    cfg.ReviewPromptTemplate,
+		Depth:             ai.Depth(cfg.ReviewDepth),
+		MaxFilePatchChars: cfg.ReviewMaxPatchChars,
+		MaxFilePatchLines: cfg.ReviewMaxPatchLines,
+		Priority:          ai.Priority(cfg.ReviewPriority),
+		PriorityPaths:     cfg.ReviewPriorityPaths,
+		MaxFiles:          cfg.ReviewMaxFiles,
+		Limiter:           ai.NewLimiter(cfg.AIRequestsPerMinute),
+		QuotaCooldown:     cfg.AIQuotaCooldown,
+		Verdict:           cfg.AIReviewGate,
+		InlineComments:    cfg.AIInlineComments,
+		Languages:         cfg.ReviewLanguages,
+		SkipTrivial:       cfg.ReviewSkipTrivial,
+		AddedLinesOnly:    cfg.ReviewAddedLinesOnly,
+	}
+	for _, lang := range cfg.ReviewLanguages {
+		if !ai.KnownLanguage(lang) {
+			log.Printf("⚠️  REVIEW_LANGUAGES entry %q does not match any detected language", lang)
+		}
+	}
+	if cfg.ReviewCacheTTL > 0 {
+		opts.Cache = ai.NewMemoryCache(cfg.ReviewCacheTTL)
+	}
+
+	var reviewer ai.Reviewer
+	switch cfg.AIProvider {
+	case "openai":
+		reviewer = ai.NewOpenAIClient(cfg.OpenAIAPIKey, cfg.OpenAIModel, opts)
+	default:
+		// Avoid storing a nil *ai.GeminiClient in the interface
+		if gemini := ai.NewGeminiClient(cfg.GeminiAPIKey, opts); gemini != nil {
+			reviewer = gemini
+		}
+	}
+	if reviewer == nil {
+		return nil
+	}
+	return ai.NewBreaker(reviewer, cfg.AIBreakerFailures, cfg.AIBreakerCooldown)
+}
+
+// NewWebhookHandlerWithClients creates a handler with the given
+// dependencies. reviewer may be nil, in which case AI review is skipped.
+func NewWebhookHandlerWithClients(cfg *config.Config, gitClient git.Client, notifier Notifier, secretScanner *scanner.Scanner, reviewer ai.Reviewer) *WebhookHandler {
 	return &WebhookHandler{
 		config:        cfg,
-		gitClient:     git.NewGitHubClient(cfg.GitHubToken, cfg.WebhookSecret),
-		slackClient:   slack.NewClient(cfg.SlackToken, cfg.SlackChannel),
-		secretScanner: scanner.NewScanner(),
-		aiClient:      ai.NewClient(cfg.GeminiAPIKey),
+		gitClient:     gitClient,
+		notifier:      notifier,
+		secretScanner: secretScanner,
+		reviewer:      reviewer,
+		approvals:     approval.NewMemoryStore(),
 	}
 }
 
+// currentConfig returns a snapshot of the active configuration
+func (h *WebhookHandler) currentConfig() *config.Config {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return h.config
+}
+
 // HandleWebhook processes incoming GitHub webhook events
 func (h *WebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 	// Only accept POST requests
@@ -44,10 +345,46 @@ func (h *WebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Read the request body
-	body, err := io.ReadAll(r.Body)
+	// Every GitHub delivery carries an ID; log it so deliveries can be
+	// traced in GitHub's webhook settings
+	deliveryID := r.Header.Get("X-GitHub-Delivery")
+	if deliveryID == "" {
+		log.Printf("Rejecting webhook without X-GitHub-Delivery header")
+		http.Error(w, "Missing delivery ID", http.StatusBadRequest)
+		return
+	}
+
+	if h.currentConfig().WebhookCheckUserAgent && !strings.HasPrefix(r.UserAgent(), githubUserAgentPrefix) {
+		log.Printf("[delivery %s] Rejecting webhook with unexpected User-Agent %q", deliveryID, r.UserAgent())
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	// Only JSON payloads are parsed; GitHub can also be set up to send
+	// form-encoded ones
+	if mediaType, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); mediaType != "application/json" {
+		log.Printf("[delivery %s] Rejecting webhook with content type %q", deliveryID, r.Header.Get("Content-Type"))
+		http.Error(w, "Content type must be application/json", http.StatusUnsupportedMediaType)
+		return
+	}
+
+	// Read the request body, refusing oversized ones before buffering
+	// them or checking their signature
+	maxBytes := int64(h.currentConfig().WebhookMaxBytes)
+	if r.ContentLength > maxBytes {
+		log.Printf("[delivery %s] Rejecting webhook of %d bytes", deliveryID, r.ContentLength)
+		http.Error(w, "Payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxBytes))
 	if err != nil {
-		log.Printf("Error reading request body: %v", err)
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			log.Printf("[delivery %s] Rejecting webhook over %d bytes", deliveryID, maxBytes)
+			http.Error(w, "Payload too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		log.Printf("[delivery %s] Error reading request body: %v", deliveryID, err)
 		http.Error(w, "Bad request", http.StatusBadRequest)
 		return
 	}
@@ -56,22 +393,33 @@ func (h *WebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 	// Verify webhook signature
 	signature := r.Header.Get("X-Hub-Signature-256")
 	if !h.gitClient.VerifyWebhook(body, signature) {
-		log.Printf("Invalid webhook signature")
+		log.Printf("[delivery %s] Invalid webhook signature", deliveryID)
 		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
 	// Get the event type
 	eventType := r.Header.Get("X-GitHub-Event")
-	log.Printf("Received GitHub event: %s", eventType)
+	log.Printf("[delivery %s] Received GitHub event: %s", deliveryID, eventType)
 
-	// We only care about pull request events
-	if eventType != "pull_request" {
+	switch eventType {
+	case "pull_request":
+		h.handlePullRequestEvent(w, body, deliveryID, h.currentConfig().SyncMode || r.URL.Query().Get("sync") == "true")
+	case "push":
+		h.handlePushEvent(w, body, deliveryID)
+	case "issue_comment":
+		h.handleIssueCommentEvent(w, body, deliveryID)
+	default:
+		h.recordIgnored(deliveryID, eventType, "", "")
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("Event ignored"))
-		return
 	}
+}
 
+// handlePullRequestEvent starts processing for a pull_request event. In
+// sync mode the PR is processed before responding and the response is a
+// JSON summary of the scan.
+func (h *WebhookHandler) handlePullRequestEvent(w http.ResponseWriter, body []byte, deliveryID string, sync bool) {
 	// Parse the webhook payload
 	var payload models.WebhookPayload
 	if err := json.Unmarshal(body, &payload); err != nil {
@@ -80,25 +428,84 @@ func (h *WebhookHandler) HandleWebhook(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Only process opened or synchronize (new commits) actions
-	if payload.Action != "opened" && payload.Action != "synchronize" {
+	// A closed PR's findings no longer need attention, nor does a scan
+	// still waiting out its debounce window
+	if payload.Action == "closed" {
+		key := fmt.Sprintf("%s#%d", payload.Repository.FullName, payload.PullRequest.Number)
+		if id := h.debounce.cancel(key); id != "" {
+			log.Printf("PR #%d: closed, dropping event %s", payload.PullRequest.Number, id)
+			h.recordIgnored(id, "pull_request", payload.Action, payload.Repository.FullName)
+		}
+		h.forgetDigest(payload.Repository.FullName, payload.PullRequest.Number)
+		h.clearApproval(key)
+		h.verdicts.clear(key)
+	}
+
+	// Only process opened, synchronize (new commits) or ready_for_review
+	// (draft marked ready) actions
+	if payload.Action != "opened" && payload.Action != "synchronize" && payload.Action != "ready_for_review" {
 		log.Printf("Ignoring action: %s", payload.Action)
+		h.recordIgnored(deliveryID, "pull_request", payload.Action, payload.Repository.FullName)
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte("Action ignored"))
 		return
 	}
 
-	// Process the PR asynchronously
-	go h.processPullRequest(payload)
+	if payload.PullRequest.Draft && !h.currentConfig().ScanDrafts {
+		log.Printf("PR #%d: skipping draft until it is ready for review", payload.PullRequest.Number)
+		h.recordIgnored(deliveryID, "pull_request", payload.Action, payload.Repository.FullName)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte("Draft ignored"))
+		return
+	}
+
+	if sync {
+		h.processPullRequestSync(w, deliveryID, payload)
+		return
+	}
+
+	// Process the PR asynchronously, after the debounce window if set
+	if window := h.currentConfig().PRDebounce; window > 0 {
+		key := fmt.Sprintf("%s#%d", payload.Repository.FullName, payload.PullRequest.Number)
+		replaced := h.debounce.add(key, deliveryID, window, func() {
+			h.trackPullRequest(context.Background(), deliveryID, payload)
+		})
+		if replaced != "" {
+			log.Printf("PR #%d: event %s superseded by %s at %s", payload.PullRequest.Number, replaced, deliveryID, payload.PullRequest.Head.SHA)
+			h.recordIgnored(replaced, "pull_request", payload.Action, payload.Repository.FullName)
+		}
+	} else {
+		go h.trackPullRequest(context.Background(), deliveryID, payload)
+	}
 
 	// Respond immediately
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("Webhook received"))
 }
 
-// processPullRequest handles the actual PR review
-func (h *WebhookHandler) processPullRequest(payload models.WebhookPayload) {
-	ctx := context.Background()
+// FlushPending processes the pull_request events still waiting out
+// PR_DEBOUNCE right away, so a shutdown doesn't drop them, and returns once
+// they are done or ctx expires. Call it after the server stops accepting
+// webhooks.
+func (h *WebhookHandler) FlushPending(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		h.debounce.flush()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("pending PR events still running: %w", ctx.Err())
+	}
+}
+
+// processPullRequest handles the actual PR review and returns a summary of
+// the outcome
+func (h *WebhookHandler) processPullRequest(ctx context.Context, payload models.WebhookPayload) (prSummary, error) {
+	cfg := h.currentConfig().ForRepo(payload.Repository.FullName)
 
 	log.Printf("Processing PR #%d from %s/%s",
 		payload.PullRequest.Number,
@@ -110,103 +517,396 @@ func (h *WebhookHandler) processPullRequest(payload models.WebhookPayload) {
 	owner := payload.Repository.Owner.Login
 	repo := payload.Repository.Name
 	prNumber := payload.PullRequest.Number
-	sha := payload.PullRequest.Head.SHA
+
+	// Statuses go to the head commit and, if configured, the merge commit
+	shas := h.statusSHAs(ctx, cfg, owner, repo, payload.PullRequest)
+
+	// Statuses link to the PR or the configured report page
+	targetURL := statusTargetURL(cfg, owner, repo, payload.PullRequest)
 
 	// Post pending status
 	log.Printf("Posting pending status to PR")
-	if err := h.gitClient.PostCommitStatus(ctx, owner, repo, sha, "pending", "GitReviewed is scanning for secrets...", "gitreviewed/security-scan"); err != nil {
+	if err := h.postStatus(ctx, owner, repo, shas, targetURL, "pending", "GitReviewed is scanning for secrets..."); err != nil {
 		log.Printf("Error posting pending status: %v", err)
 	}
 
-	diffFiles, err := h.gitClient.GetPRDiff(ctx, owner, repo, prNumber)
+	// Files marked linguist-generated are left out of the AI review, as
+	// GitHub hides them in diffs, and their findings may be downgraded
+	generated := h.generatedFiles(ctx, cfg, owner, repo)
+	var skipGenerated *git.Attributes
+	if cfg.SkipGeneratedFiles {
+		skipGenerated = generated
+	}
+
+	// Scan for secrets page by page, keeping only the files the AI will
+	// review
+	scanResult, files, err := h.scanPRFiles(ctx, cfg, owner, repo, payload.PullRequest, skipGenerated)
 	if err != nil {
 		log.Printf("Error fetching PR diff: %v", err)
-		// Post error status
-		h.gitClient.PostCommitStatus(ctx, owner, repo, sha, "error", "Failed to fetch PR diff", "gitreviewed/security-scan")
-		return
+		h.reportFailure(fmt.Sprintf("Failed to fetch the diff of %s/%s#%d", owner, repo, prNumber), err)
+		// Post error status; a permissions problem won't fix itself, so
+		// say so rather than suggest a retry
+		description := "Failed to fetch PR diff"
+		if errors.Is(err, apierr.ErrAuth) {
+			description = "GitHub denied access to the PR diff"
+		}
+		h.postStatus(ctx, owner, repo, shas, targetURL, "error", description)
+		return prSummary{}, fmt.Errorf("failed to fetch PR diff: %w", err)
+	}
+
+	log.Printf("Scanned %d files from PR #%d", scanResult.TotalFiles, prNumber)
+	unscanned := scanResult.UnscannedFiles
+
+	// Secrets in commit messages end up in history just like file contents
+	commits, err := h.gitClient.ListPRCommits(ctx, owner, repo, prNumber)
+	if err != nil {
+		log.Printf("Error fetching PR commits, skipping commit message scan: %v", err)
+	} else if commitIssues := h.secretScanner.ScanCommits(commits); len(commitIssues) > 0 {
+		scanResult.Issues = append(scanResult.Issues, commitIssues...)
+		scanResult.Found = true
 	}
 
-	log.Printf("Fetched %d files from PR #%d", len(diffFiles), prNumber)
+	// Apply per-repo disabled patterns and the organization policy's
+	// allowlist and excluded paths
+	scanResult = withoutRepoPatterns(cfg, payload.Repository.FullName, scanResult)
+	orgPolicy := h.currentPolicy(ctx)
+	scanResult = orgPolicy.Apply(scanResult)
+	scanResult.Issues = scanner.AdjustSeverities(scanResult.Issues, severityRules(cfg))
+
+	downgradeGenerated(scanResult.Issues, generated, cfg.GeneratedDowngrade)
+	generatedOnly := files.generated > 0 && files.total == 0
+	diffFiles := files.review
 
-	// Scan for secrets
-	scanResult := h.secretScanner.ScanFiles(diffFiles)
-	scanResult.ScannedAt = time.Now()
+	tagProjects(cfg, scanResult.Issues)
+	if len(unscanned) > 0 {
+		log.Printf("⚠️  %d file(s) could not be scanned: %v", len(unscanned), unscanned)
+	}
 
 	log.Printf("Scan complete: found %d issues", len(scanResult.Issues))
 
 	// Build review context
 	reviewCtx := models.ReviewContext{
-		Repository:  payload.Repository,
-		PullRequest: payload.PullRequest,
-		DiffFiles:   diffFiles,
-		ScanResult:  scanResult,
+		Repository:      payload.Repository,
+		PullRequest:     payload.PullRequest,
+		DiffFiles:       diffFiles,
+		ScanResult:      scanResult,
+		UnreviewedFiles: files.unreviewed(),
+		Fresh:           payload.Action == commandReview || h.forcePushed(ctx, cfg, owner, repo, payload),
 	}
+	reviewCtx.AuthorHistory = h.trackAuthor(ctx, payload.Repository, payload.PullRequest, scanResult)
+
+	// Determine if there are issues at or above the block threshold
+	threshold := blockThreshold(cfg, payload.Repository.FullName, orgPolicy)
+	blocking := projectBlockingIssues(cfg, threshold, scanResult.Issues)
+	blockingCount := len(blocking)
 
-	// Determine if there are CRITICAL issues
-	hasCriticalSecrets := false
-	criticalCount := 0
-	for _, issue := range scanResult.Issues {
-		if issue.Severity == "CRITICAL" {
-			hasCriticalSecrets = true
-			criticalCount++
+	// Post status based on scan results. In approval mode a block holds
+	// until a security reviewer approves it, even once the secrets are
+	// gone.
+	state, statusMsg := statusPolicy(cfg, threshold)(scanResult)
+	awaitingApproval := false
+	if cfg.RequireBlockApproval {
+		key := fmt.Sprintf("%s#%d", payload.Repository.FullName, prNumber)
+		if blockingCount > 0 {
+			h.blockApproval(key)
+		} else if h.approvals.Awaiting(key) {
+			awaitingApproval = true
+			state, statusMsg = "failure", awaitingApprovalDescription
 		}
 	}
+	log.Printf("Posting %s status: %s", state, statusMsg)
+	if err := h.postStatus(ctx, owner, repo, shas, targetURL, state, statusMsg); err != nil {
+		log.Printf("Error posting %s status: %v", state, err)
+	}
 
-	// Post status based on scan results
-	if hasCriticalSecrets {
-		// BLOCK the PR - set status to failure
-		statusMsg := fmt.Sprintf("❌ Found %d critical secret(s) - merge blocked!", criticalCount)
-		log.Printf("Posting failure status: %s", statusMsg)
-		if err := h.gitClient.PostCommitStatus(ctx, owner, repo, sha, "failure", statusMsg, "gitreviewed/security-scan"); err != nil {
-			log.Printf("Error posting failure status: %v", err)
-		}
-	} else if scanResult.Found {
-		// Has non-critical issues - warn but don't block
-		statusMsg := fmt.Sprintf("⚠️  Found %d non-critical issue(s) - review recommended", len(scanResult.Issues))
-		log.Printf("Posting success status with warning: %s", statusMsg)
-		if err := h.gitClient.PostCommitStatus(ctx, owner, repo, sha, "success", statusMsg, "gitreviewed/security-scan"); err != nil {
-			log.Printf("Error posting status: %v", err)
-		}
-	} else {
-		// No secrets found - all clear!
-		statusMsg := "✅ No secrets detected - safe to merge"
-		log.Printf("Posting success status: %s", statusMsg)
-		if err := h.gitClient.PostCommitStatus(ctx, owner, repo, sha, "success", statusMsg, "gitreviewed/security-scan"); err != nil {
-			log.Printf("Error posting success status: %v", err)
-		}
+	h.auditDecision(ctx, payload.Repository, payload.PullRequest, threshold, blocking)
+	// A pending approval keeps the change request in place
+	if !awaitingApproval {
+		h.submitReview(ctx, cfg, owner, repo, reviewCtx, blocking)
 	}
 
-	// Send security alert if issues found
-	if scanResult.Found {
+	// Send security alert if issues found, one per sub-project with its
+	// own channel, unless the findings can wait for the digest
+	h.recordDigest(payload.Repository, payload.PullRequest, scanResult.Issues)
+	if scanResult.Found && digestOnly(cfg, scanResult.Issues) {
+		log.Printf("No findings at or above %s, leaving the alert for PR #%d to the digest", cfg.DigestImmediateSeverity, prNumber)
+	} else if scanResult.Found {
 		log.Printf("Sending security alert to Slack")
-		if err := h.slackClient.SendSecurityAlert(reviewCtx); err != nil {
-			log.Printf("Error sending Slack alert: %v", err)
+		for _, result := range splitByProject(cfg, scanResult) {
+			alertCtx := reviewCtx
+			alertCtx.ScanResult = result
+			if err := h.notifier.SendSecurityAlert(alertCtx); err != nil {
+				log.Printf("Error sending Slack alert: %v", err)
+				h.reportFailure(fmt.Sprintf("Failed to send the security alert for %s/%s#%d", owner, repo, prNumber), err)
+			}
 		}
 	}
 
 	// Get AI code review (per-file approach)
-	log.Printf("Requesting AI code review for %d files", len(diffFiles))
-	aiReview, err := h.aiClient.ReviewCodeByFile(reviewCtx)
-	if err != nil {
-		log.Printf("⚠️  AI review failed: %v", err)
-		
+	var aiReview models.ReviewResult
+	aiErr := errAIUnavailable
+	var skipReason string
+	author := payload.PullRequest.User.Login
+	if cfg.DisableAIReview {
+		aiErr = errAIDisabled
+	} else if payload.Action == commandRescan || payload.Action == commandApprove {
+		aiErr = errRescanOnly
+	} else if !reviewsAuthor(cfg, author) {
+		// Bot PRs are common enough that a Slack message for each would be
+		// noise, so this is only logged
+		log.Printf("PR #%d by %s: author skipped for AI review", prNumber, author)
+		aiErr = fmt.Errorf("%w: author %s is not reviewed", errReviewSkipped, author)
+	} else if generatedOnly {
+		log.Printf("PR #%d only changes generated files, skipping AI review", prNumber)
+		aiErr = fmt.Errorf("%w: only generated files changed", errReviewSkipped)
+	} else if nonCodeOnly(diffFiles) {
+		log.Printf("PR #%d only changes binary or non-code files, skipping AI review", prNumber)
+		aiErr = fmt.Errorf("%w: only non-code files changed", errReviewSkipped)
+	} else if otherLanguagesOnly(cfg, diffFiles) {
+		log.Printf("PR #%d changes no files in REVIEW_LANGUAGES, skipping AI review", prNumber)
+		aiErr = fmt.Errorf("%w: no files in the reviewed languages changed", errReviewSkipped)
+	} else if trivialOnly(cfg, diffFiles) {
+		log.Printf("PR #%d only changes whitespace or comments, skipping AI review", prNumber)
+		aiErr = fmt.Errorf("%w: no substantive changes", errReviewSkipped)
+	} else if reason := smallPRReason(cfg, files.codeLines); reason != "" {
+		aiErr = fmt.Errorf("%w: %s", errReviewSkipped, reason)
+		if cfg.MinReviewNotify {
+			skipReason = reason
+		} else {
+			log.Printf("Skipping AI review of PR #%d: %s", prNumber, reason)
+		}
+	} else if skipReason = reviewSkipReason(cfg, files.lines); skipReason != "" {
+		aiErr = fmt.Errorf("%w: %s", errReviewSkipped, skipReason)
+	} else if h.reviewer != nil {
+		log.Printf("Requesting AI code review for %d files", len(diffFiles))
+		aiReview, aiErr = h.reviewer.ReviewCodeByFile(ctx, reviewCtx)
+		if errors.Is(aiErr, ai.ErrNothingToReview) {
+			aiErr = fmt.Errorf("%w: %v", errReviewSkipped, aiErr)
+		}
+	}
+	if skipReason != "" {
+		log.Printf("Skipping AI review of PR #%d: %s", prNumber, skipReason)
+
+		// The skipped message includes the scan results, so it replaces
+		// the review complete message
+		if scanResult.Found || cfg.NotifyOnClean {
+			if err := h.notifier.SendReviewSkipped(reviewCtx, skipReason); err != nil {
+				log.Printf("Error sending review skipped message: %v", err)
+			}
+		}
+	} else if aiErr != nil {
+		if !errors.Is(aiErr, errReviewSkipped) {
+			log.Printf("⚠️  AI review failed: %v", aiErr)
+			if !errors.Is(aiErr, errAIUnavailable) && !errors.Is(aiErr, ai.ErrCircuitOpen) {
+				h.reportFailure(fmt.Sprintf("AI review failed for %s/%s#%d", owner, repo, prNumber), aiErr)
+			}
+		}
+
+		// A quota error affects every file, so report it once per PR
+		if errors.Is(aiErr, ai.ErrQuotaExceeded) {
+			if err := h.notifier.SendAIUnavailable(reviewCtx, "quota exceeded"); err != nil {
+				log.Printf("Error sending AI unavailable message: %v", err)
+			}
+		}
+		if errors.Is(aiErr, ai.ErrCircuitOpen) {
+			if err := h.notifier.SendAIUnavailable(reviewCtx, "temporarily unavailable after repeated failures"); err != nil {
+				log.Printf("Error sending AI unavailable message: %v", err)
+			}
+		}
+
 		// Still send a message that secret scanning completed
-		if !scanResult.Found {
-			if err := h.slackClient.SendReviewComplete(reviewCtx); err != nil {
+		if !scanResult.Found && cfg.NotifyOnClean {
+			if err := h.notifier.SendReviewComplete(reviewCtx); err != nil {
 				log.Printf("Error sending review complete message: %v", err)
 			}
 		}
 	} else {
-		log.Printf("AI review received for all files, sending to Slack")
-		if err := h.slackClient.SendAIReview(reviewCtx, aiReview); err != nil {
-			log.Printf("Error sending AI review to Slack: %v", err)
+		if n := len(aiReview.Failed); n > 0 {
+			log.Printf("⚠️  AI review failed for %d of %d file(s)", n, aiReview.Reviewed+n)
+		}
+		if !scanResult.Found && !cfg.NotifyOnClean {
+			log.Printf("AI review received, not notifying Slack for a clean PR")
+		} else {
+			log.Printf("AI review received, sending to Slack")
+			if err := h.notifier.SendAIReview(reviewCtx, aiReview); err != nil {
+				log.Printf("Error sending AI review to Slack: %v", err)
+				h.reportFailure(fmt.Sprintf("Failed to send the AI review for %s/%s#%d", owner, repo, prNumber), err)
+			}
+		}
+		h.postInlineReview(ctx, cfg, owner, repo, reviewCtx, aiReview, files.newLines)
+	}
+
+	h.postAIStatus(ctx, cfg, owner, repo, payload.PullRequest, aiReview, aiErr)
+	h.postVerdictStatus(ctx, cfg, owner, repo, shas, payload.PullRequest, fmt.Sprintf("%s#%d", payload.Repository.FullName, prNumber), state, aiReview, aiErr)
+
+	// Keep a single summary comment on the PR up to date
+	if cfg.PRComment {
+		body := buildPRComment(reviewCtx, blockingCount, aiReview, aiErr)
+		if err := h.gitClient.UpsertPRComment(ctx, owner, repo, prNumber, summaryCommentMarker, body); err != nil {
+			log.Printf("Error updating PR summary comment: %v", err)
 		}
 	}
 
 	log.Printf("Completed processing PR #%d", prNumber)
+	return newPRSummary(reviewCtx, blocking, aiReview, aiErr), nil
+}
+
+// blockingIssues returns the issues at or above the threshold severity
+func blockingIssues(threshold string, issues []models.SecurityIssue) []models.SecurityIssue {
+	var blocking []models.SecurityIssue
+	for _, issue := range issues {
+		if scanner.SeverityRank(issue.Severity) >= scanner.SeverityRank(threshold) {
+			blocking = append(blocking, issue)
+		}
+	}
+	return blocking
+}
+
+// resolveMissingPatches returns the files to scan for secrets. Files whose
+// patch GitHub omitted are replaced by their full content at ref so they
+// are not silently skipped; files that still can't be read are returned
+// as unscanned, and kept without a patch so they are still checked by
+// name. With scanRenamed, files renamed without changes are
+// fetched and scanned too. Findings are reported under the new path.
+func (h *WebhookHandler) resolveMissingPatches(ctx context.Context, owner, repo, ref string, files []models.DiffFile, scanRenamed bool) ([]models.DiffFile, []string) {
+	scanFiles := make([]models.DiffFile, 0, len(files))
+	var unscanned []string
+
+	for _, file := range files {
+		// Files the scanner skips aren't worth fetching
+		if (!file.PatchMissing() && !(scanRenamed && file.RenamedWithoutPatch())) || h.secretScanner.SkipsFile(file.Filename) {
+			scanFiles = append(scanFiles, file)
+			continue
+		}
+
+		content, err := h.gitClient.GetFileContent(ctx, owner, repo, file.Filename, ref)
+		if err != nil {
+			log.Printf("Could not fetch content of %s: %v", file.Filename, err)
+			unscanned = append(unscanned, file.Filename)
+			// Still checked by name, e.g. for binary key files
+			scanFiles = append(scanFiles, file)
+			continue
+		}
+
+		file.Patch = scanner.ContentAsDiff(content)
+		scanFiles = append(scanFiles, file)
+	}
+
+	return scanFiles, unscanned
+}
+
+// forcePushed reports whether a synchronize event rewrote the PR's history
+// instead of adding commits to it, in which case cached AI reviews are not
+// reused. A previous head that can't be compared, e.g. because GitHub has
+// already dropped it, counts as a force-push. Without a review cache there
+// is nothing to bypass, so the commits aren't compared.
+func (h *WebhookHandler) forcePushed(ctx context.Context, cfg *config.Config, owner, repo string, payload models.WebhookPayload) bool {
+	if cfg.ReviewCacheTTL <= 0 || h.reviewer == nil {
+		return false
+	}
+	if payload.Action != "synchronize" || payload.Before == "" || payload.After == "" {
+		return false
+	}
+
+	status, err := h.gitClient.CompareStatus(ctx, owner, repo, payload.Before, payload.After)
+	if err != nil {
+		log.Printf("PR #%d: could not compare %s with %s, treating the push as a force-push: %v",
+			payload.PullRequest.Number, models.ShortSHA(payload.Before), models.ShortSHA(payload.After), err)
+		return true
+	}
+	if status == "ahead" || status == "identical" {
+		return false
+	}
+	log.Printf("PR #%d: force-pushed from %s to %s (%s), reviewing without the cache",
+		payload.PullRequest.Number, models.ShortSHA(payload.Before), models.ShortSHA(payload.After), status)
+	return true
+}
+
+// statusSHAs returns the commits that should receive status updates for a PR
+func (h *WebhookHandler) statusSHAs(ctx context.Context, cfg *config.Config, owner, repo string, pr models.PullRequest) []string {
+	shas := []string{pr.Head.SHA}
+	if !cfg.PostMergeCommitStatus {
+		return shas
+	}
+
+	// The webhook payload often omits the merge commit while GitHub is
+	// still computing mergeability, so fall back to fetching the PR
+	mergeSHA := pr.MergeCommitSHA
+	if mergeSHA == "" {
+		info, err := h.gitClient.GetPRInfo(ctx, owner, repo, pr.Number)
+		if err != nil {
+			log.Printf("Error fetching merge commit SHA: %v", err)
+		} else {
+			mergeSHA = info.MergeCommitSHA
+		}
+	}
+
+	if mergeSHA == "" || mergeSHA == pr.Head.SHA {
+		return shas
+	}
+	return append(shas, mergeSHA)
 }
 
-// HealthCheck handles health check requests
+// postStatus posts a security scan status to each of the given commits,
+// linking to targetURL; the details behind a shortened description are
+// found there
+func (h *WebhookHandler) postStatus(ctx context.Context, owner, repo string, shas []string, targetURL, state, description string) error {
+	var firstErr error
+	for _, sha := range shas {
+		if sha == "" {
+			continue
+		}
+		if err := h.gitClient.PostCommitStatus(ctx, owner, repo, sha, state, description, "gitreviewed/security-scan", targetURL); err != nil {
+			log.Printf("🚨 Could not post %q status to %s/%s@%s; the PR may show a stale status: %v", state, owner, repo, models.ShortSHA(sha), err)
+			if firstErr == nil {
+				firstErr = err
+			}
+		}
+	}
+	return firstErr
+}
+
+// postAIStatus reports on the head commit whether the AI review covered
+// the PR. Commit statuses have no neutral state, so a review with too many
+// failed files is reported as "error". With the review gate, a
+// request-changes verdict is reported as "failure".
+func (h *WebhookHandler) postAIStatus(ctx context.Context, cfg *config.Config, owner, repo string, pr models.PullRequest, review models.ReviewResult, aiErr error) {
+	// A rescan leaves the last AI review, and its status, in place
+	if !(cfg.AIStatus || cfg.AIReviewGate) || h.reviewer == nil || errors.Is(aiErr, errRescanOnly) {
+		return
+	}
+
+	state := "success"
+	description := fmt.Sprintf("AI reviewed %d file(s)", review.Reviewed)
+	switch {
+	case errors.Is(aiErr, errReviewSkipped) && cfg.AIReviewGate:
+		// The gate has no verdict to pass, so the PR waits on a person
+		state = "pending"
+		description = fmt.Sprintf("%s - needs a manual review", aiErr)
+	case errors.Is(aiErr, errReviewSkipped):
+		description = "AI review skipped for this PR"
+	case errors.Is(aiErr, ai.ErrCircuitOpen):
+		state = "error"
+		description = "AI review temporarily unavailable"
+	case aiErr != nil:
+		state = "error"
+		description = "AI review could not be completed"
+	case len(review.Failed) > 0 && review.FailedPercent() >= cfg.AIFailurePercent:
+		state = "error"
+		description = fmt.Sprintf("AI review incomplete: %d of %d file(s) failed", len(review.Failed), review.Reviewed+len(review.Failed))
+	case cfg.AIReviewGate && review.Verdict == ai.VerdictRequestChanges:
+		state = "failure"
+		description = "AI review requested changes"
+	case cfg.AIReviewGate && review.Verdict == ai.VerdictApprove:
+		description = fmt.Sprintf("AI review approved %d file(s)", review.Reviewed)
+	}
+
+	if err := h.gitClient.PostCommitStatus(ctx, owner, repo, pr.Head.SHA, state, description, "gitreviewed/ai-review", statusTargetURL(cfg, owner, repo, pr)); err != nil {
+		log.Printf("Error posting AI review status: %v", err)
+	}
+}
+
+// HealthCheck handles liveness probes; see Ready for dependency checks
 func (h *WebhookHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write([]byte("OK"))
@@ -214,7 +914,7 @@ func (h *WebhookHandler) HealthCheck(w http.ResponseWriter, r *http.Request) {
 
 // TestSlack tests the Slack connection
 func (h *WebhookHandler) TestSlack(w http.ResponseWriter, r *http.Request) {
-	if err := h.slackClient.TestConnection(); err != nil {
+	if err := h.notifier.TestConnection(); err != nil {
 		http.Error(w, fmt.Sprintf("Slack connection failed: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -223,13 +923,187 @@ func (h *WebhookHandler) TestSlack(w http.ResponseWriter, r *http.Request) {
 	w.Write([]byte("Slack connection successful"))
 }
 
-// TestGemini tests the Gemini API connection
+// TestGemini tests the AI backend connection
 func (h *WebhookHandler) TestGemini(w http.ResponseWriter, r *http.Request) {
-	if err := h.aiClient.TestConnection(); err != nil {
-		http.Error(w, fmt.Sprintf("Gemini connection failed: %v", err), http.StatusInternalServerError)
+	if !h.currentConfig().AIReviewUsed() {
+		http.Error(w, "AI review is disabled", http.StatusServiceUnavailable)
+		return
+	}
+	if h.reviewer == nil {
+		http.Error(w, "AI review is not configured or its client failed to start", http.StatusServiceUnavailable)
+		return
+	}
+
+	if err := h.reviewer.TestConnection(); err != nil {
+		http.Error(w, fmt.Sprintf("AI connection failed: %v", err), http.StatusInternalServerError)
 		return
 	}
 
 	w.WriteHeader(http.StatusOK)
-	w.Write([]byte("Gemini connection successful"))
-}
\ No newline at end of file
+	w.Write([]byte(fmt.Sprintf("AI connection successful (model %s)", h.reviewer.Model())))
+}
+
+// Reload re-reads the pattern file, baseline and mutable configuration and swaps
+// them into the running handler. PRs already being processed keep the
+// settings they started with.
+func (h *WebhookHandler) Reload(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	h.patternEdits.Lock()
+	defer h.patternEdits.Unlock()
+
+	cfg := h.currentConfig()
+	next, err := cfg.Reload()
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Reload failed: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	patterns, err := loadPatterns(next)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Reload failed: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	baseline, err := scanner.LoadBaseline(next.BaselineFile)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Reload failed: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	changes := diffPatterns(h.secretScanner.Patterns(), patterns)
+	if cfg.BlockSeverity != next.BlockSeverity {
+		changes = append(changes, fmt.Sprintf("block severity: %s -> %s", cfg.BlockSeverity, next.BlockSeverity))
+	}
+
+	h.mu.Lock()
+	h.config = next
+	h.orgPolicy = newPolicyCache(next, h.gitClient)
+	h.secretScanner.SetPatterns(patterns)
+	h.secretScanner.SetBaseline(baseline)
+	h.secretScanner.SetConcurrency(next.ScanConcurrency)
+	h.secretScanner.SetSensitiveFileSeverity(sensitiveFileSeverity(next))
+	h.secretScanner.SetKeyFileCheck(keyFileCheck(next))
+	h.secretScanner.SetDecodeBase64(next.ScanBase64)
+	if setter, ok := h.notifier.(slackOptionsSetter); ok {
+		setter.SetOptions(slackOptions(next))
+	}
+	h.mu.Unlock()
+
+	log.Printf("Configuration reloaded: %d change(s)", len(changes))
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"patterns": len(patterns),
+		"changes":  changes,
+	})
+}
+
+// slackChannels returns the configured channel routes plus a route for
+// each sub-project and repository with its own channel
+func slackChannels(cfg *config.Config) map[string]string {
+	if len(cfg.Projects) == 0 && len(cfg.Repos) == 0 {
+		return cfg.SlackChannels
+	}
+
+	channels := make(map[string]string, len(cfg.SlackChannels)+len(cfg.Projects)+len(cfg.Repos))
+	for route, channel := range cfg.SlackChannels {
+		channels[route] = channel
+	}
+	for _, project := range cfg.Projects {
+		if project.SlackChannel != "" {
+			channels[slack.ProjectRoute(project.Name)] = project.SlackChannel
+		}
+	}
+	for repo, settings := range cfg.Repos {
+		if settings.SlackChannel != "" {
+			channels[slack.RepoRoute(repo)] = settings.SlackChannel
+		}
+	}
+	return channels
+}
+
+// slackOptions builds the Slack client options from the configuration
+func slackOptions(cfg *config.Config) slack.Options {
+	return slack.Options{
+		AlertStyle: alertStyle(cfg),
+		Channels:   slackChannels(cfg),
+
+		MaxReviewChars:   cfg.SlackMaxReviewChars,
+		UploadFullReview: cfg.SlackUploadFullReview,
+	}
+}
+
+// alertStyle builds the Slack alert style from the configuration
+func alertStyle(cfg *config.Config) slack.AlertStyle {
+	style := slack.AlertStyle{
+		ActionText:   cfg.SlackActionText,
+		DisableEmoji: cfg.SlackDisableEmoji,
+		Severities:   make(map[string]slack.SeverityLabel, len(cfg.SlackSeverityLabels)),
+
+		MaxIssuesPerSeverity: cfg.SlackMaxIssues,
+		MaxBlocks:            cfg.SlackMaxBlocks,
+	}
+	for severity, label := range cfg.SlackSeverityLabels {
+		style.Severities[severity] = slack.SeverityLabel{Emoji: label.Emoji, Label: label.Label}
+	}
+	// Mentions are validated with the configuration, so only configured
+	// severities ever ping anyone
+	if len(cfg.SlackMentions) > 0 {
+		style.Mentions = make(map[string]string, len(cfg.SlackMentions))
+		for severity, value := range cfg.SlackMentions {
+			if mention, ok := config.SlackMention(value); ok {
+				style.Mentions[severity] = mention
+			}
+		}
+	}
+	return style
+}
+
+// authorized checks the request's bearer token against the admin token.
+// Admin endpoints are disabled when no token is configured.
+func (h *WebhookHandler) authorized(r *http.Request) bool {
+	token := h.currentConfig().AdminToken
+	if token == "" {
+		return false
+	}
+	given := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return subtle.ConstantTimeCompare([]byte(given), []byte(token)) == 1
+}
+
+// diffPatterns describes the differences between two pattern sets
+func diffPatterns(before, after []scanner.SecretPattern) []string {
+	changes := []string{}
+
+	beforeByName := make(map[string]scanner.SecretPattern, len(before))
+	for _, p := range before {
+		beforeByName[p.Name] = p
+	}
+
+	seen := make(map[string]bool, len(after))
+	for _, p := range after {
+		seen[p.Name] = true
+		prev, ok := beforeByName[p.Name]
+		switch {
+		case !ok:
+			changes = append(changes, "added pattern: "+p.Name)
+		case prev.Pattern.String() != p.Pattern.String() || prev.Severity != p.Severity || prev.MinLength != p.MinLength || prev.Multiline != p.Multiline:
+			changes = append(changes, "updated pattern: "+p.Name)
+		}
+	}
+
+	for _, p := range before {
+		if !seen[p.Name] {
+			changes = append(changes, "removed pattern: "+p.Name)
+		}
+	}
+
+	return changes
+}
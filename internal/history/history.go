@@ -0,0 +1,34 @@
+package history
+
+import (
+	"context"
+	"time"
+)
+
+// Offense records the findings in one pull request by an author
+type Offense struct {
+	Author   string
+	PR       string // "owner/name#number"
+	Findings int
+	Time     time.Time
+}
+
+// Store keeps per-author finding history. Implementations must be safe for
+// concurrent use.
+type Store interface {
+	// Record stores the findings for a PR, replacing any earlier record for
+	// the same PR so re-scans are not counted twice
+	Record(ctx context.Context, offense Offense) error
+
+	// Prior returns the author's recorded offenses in PRs other than pr
+	Prior(ctx context.Context, author, pr string) ([]Offense, error)
+}
+
+// Summarize returns the number of PRs and total findings in offenses
+func Summarize(offenses []Offense) (prs, findings int) {
+	for _, offense := range offenses {
+		prs++
+		findings += offense.Findings
+	}
+	return prs, findings
+}
@@ -0,0 +1,83 @@
+package history
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryStore keeps author history in memory. Offenses older than the
+// retention period are dropped; history is lost on restart.
+type MemoryStore struct {
+	retention time.Duration
+
+	mu       sync.Mutex
+	offenses map[string]map[string]Offense // author -> PR -> offense
+}
+
+// NewMemoryStore creates an in-memory store that forgets offenses after
+// retention; zero or less keeps them forever
+func NewMemoryStore(retention time.Duration) *MemoryStore {
+	return &MemoryStore{
+		retention: retention,
+		offenses:  make(map[string]map[string]Offense),
+	}
+}
+
+// Record stores the findings for a PR
+func (m *MemoryStore) Record(ctx context.Context, offense Offense) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	author := strings.ToLower(offense.Author)
+	if m.offenses[author] == nil {
+		m.offenses[author] = make(map[string]Offense)
+	}
+	m.offenses[author][offense.PR] = offense
+	m.prune(author)
+	return nil
+}
+
+// Prior returns the author's offenses in PRs other than pr, oldest first
+func (m *MemoryStore) Prior(ctx context.Context, author, pr string) ([]Offense, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	author = strings.ToLower(author)
+	m.prune(author)
+
+	var prior []Offense
+	for key, offense := range m.offenses[author] {
+		if key != pr {
+			prior = append(prior, offense)
+		}
+	}
+	sortByTime(prior)
+	return prior, nil
+}
+
+// prune drops the author's offenses older than the retention period
+func (m *MemoryStore) prune(author string) {
+	if m.retention <= 0 {
+		return
+	}
+
+	cutoff := time.Now().Add(-m.retention)
+	for key, offense := range m.offenses[author] {
+		if offense.Time.Before(cutoff) {
+			delete(m.offenses[author], key)
+		}
+	}
+	if len(m.offenses[author]) == 0 {
+		delete(m.offenses, author)
+	}
+}
+
+// sortByTime orders offenses from oldest to newest
+func sortByTime(offenses []Offense) {
+	sort.Slice(offenses, func(i, j int) bool {
+		return offenses[i].Time.Before(offenses[j].Time)
+	})
+}
@@ -1,12 +1,23 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Rishav176/GitReviewed/internal/diff"
+)
 
 // WebhookPayload represents the incoming webhook from GitHub
 type WebhookPayload struct {
 	Action      string      `json:"action"`
 	PullRequest PullRequest `json:"pull_request"`
 	Repository  Repository  `json:"repository"`
+	// Before and After are the PR's previous and new head commits on a
+	// synchronize event
+	Before string `json:"before"`
+	After  string `json:"after"`
 }
 
 // PullRequest contains PR details from GitHub
@@ -20,15 +31,63 @@ type PullRequest struct {
 	User      User      `json:"user"`
 	Head      GitRef    `json:"head"`
 	Base      GitRef    `json:"base"`
+	// MergeCommitSHA is GitHub's test merge commit; it may be empty while
+	// mergeability is still being computed
+	MergeCommitSHA string `json:"merge_commit_sha"`
+	Draft          bool   `json:"draft"`
+}
+
+// PushPayload represents an incoming push webhook from GitHub
+type PushPayload struct {
+	Ref        string     `json:"ref"`
+	Before     string     `json:"before"`
+	After      string     `json:"after"`
+	Created    bool       `json:"created"`
+	Deleted    bool       `json:"deleted"`
+	Forced     bool       `json:"forced"`
+	Compare    string     `json:"compare"`
+	Repository Repository `json:"repository"`
+	Sender     User       `json:"sender"`
+}
+
+// Branch returns the pushed branch name, or "" for non-branch refs
+func (p PushPayload) Branch() string {
+	if !strings.HasPrefix(p.Ref, "refs/heads/") {
+		return ""
+	}
+	return strings.TrimPrefix(p.Ref, "refs/heads/")
+}
+
+// IssueCommentPayload is an issue_comment webhook. Comments on pull
+// requests arrive as issue comments whose issue has a pull_request link.
+type IssueCommentPayload struct {
+	Action string `json:"action"`
+	Issue  struct {
+		Number      int  `json:"number"`
+		User        User `json:"user"`
+		PullRequest *struct {
+			URL string `json:"url"`
+		} `json:"pull_request"`
+	} `json:"issue"`
+	Comment struct {
+		Body string `json:"body"`
+		User User   `json:"user"`
+		// AuthorAssociation is the commenter's relation to the repository,
+		// e.g. OWNER, MEMBER, COLLABORATOR or CONTRIBUTOR
+		AuthorAssociation string `json:"author_association"`
+	} `json:"comment"`
+	Repository Repository `json:"repository"`
 }
 
 // Repository contains repo information
 type Repository struct {
-	ID       int64  `json:"id"`
-	Name     string `json:"name"`
-	FullName string `json:"full_name"`
-	Private  bool   `json:"private"`
-	Owner    User   `json:"owner"`
+	ID            int64  `json:"id"`
+	Name          string `json:"name"`
+	FullName      string `json:"full_name"`
+	Private       bool   `json:"private"`
+	Owner         User   `json:"owner"`
+	HTMLURL       string `json:"html_url"`
+	DefaultBranch string `json:"default_branch"`
 }
 
 // User represents a GitHub user
@@ -36,6 +95,7 @@ type User struct {
 	Login     string `json:"login"`
 	ID        int64  `json:"id"`
 	AvatarURL string `json:"avatar_url"`
+	Type      string `json:"type"` // "User" or "Bot"
 }
 
 // GitRef represents a git reference (branch)
@@ -44,14 +104,57 @@ type GitRef struct {
 	SHA string `json:"sha"`
 }
 
+// Issue represents a GitHub issue
+type Issue struct {
+	Number  int
+	Title   string
+	Body    string
+	HTMLURL string
+}
+
+// Commit is a commit in a pull request
+type Commit struct {
+	SHA     string
+	Message string
+	Author  string
+}
+
+// ShortSHA abbreviates a commit SHA for display
+func ShortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
 // DiffFile represents a single file change in a PR
 type DiffFile struct {
 	Filename  string
-	Status    string // "added", "modified", "removed"
+	Status    string // "added", "modified", "removed", "renamed"
 	Additions int
 	Deletions int
 	Changes   int
 	Patch     string // The actual diff content
+	// PreviousFilename is the old path of a renamed file
+	PreviousFilename string
+}
+
+// PatchMissing reports whether GitHub omitted the patch for a changed
+// file, which happens for very large and binary files
+func (f DiffFile) PatchMissing() bool {
+	return f.Patch == "" && f.Status != "removed" && f.Changes > 0
+}
+
+// RenamedWithoutPatch reports whether the file was renamed without any
+// content changes, so GitHub sends no patch for it
+func (f DiffFile) RenamedWithoutPatch() bool {
+	return f.Status == "renamed" && f.Patch == ""
+}
+
+// NewLines returns the lines of the new file that appear in the patch,
+// added or unchanged, which are the lines a review comment can be placed on
+func (f DiffFile) NewLines() map[int]bool {
+	return diff.Parse(f.Patch).NewLines()
 }
 
 // ScanResult contains the results of security scanning
@@ -60,16 +163,70 @@ type ScanResult struct {
 	Issues     []SecurityIssue
 	ScannedAt  time.Time
 	TotalFiles int
+	// UnscannedFiles lists files whose content could not be scanned, e.g.
+	// because GitHub omitted the patch and the file was too large to fetch
+	UnscannedFiles []string
 }
 
 // SecurityIssue represents a detected security problem
 type SecurityIssue struct {
-	Type        string // e.g., "AWS Access Key", "GitHub Token"
-	FilePath    string
-	LineNumber  int
-	Severity    string // "CRITICAL", "HIGH", "MEDIUM", "LOW"
+	Type       string // e.g., "AWS Access Key", "GitHub Token"
+	FilePath   string
+	LineNumber int
+	// Cell is the 1-based notebook cell of a finding in a Jupyter
+	// notebook, and 0 otherwise or when it isn't known
+	Cell int
+	// StartColumn and EndColumn are the 1-based, inclusive byte columns
+	// of the secret on its line, and 0 when not known
+	StartColumn int
+	EndColumn   int
+	// Match is the matched secret, redacted
+	Match    string
+	Severity string // "CRITICAL", "HIGH", "MEDIUM", "LOW"
+	// OriginalSeverity is the pattern's severity when a path rule changed
+	// Severity, and empty otherwise
+	OriginalSeverity string
+	// Project is the monorepo sub-project the file belongs to, and empty
+	// outside every project
+	Project     string
 	Description string
+	// Remediation is the pattern's suggested next step, if any
+	Remediation string
 	Pattern     string // Which pattern matched
+	Fingerprint string // Stable ID from pattern, path and matched value
+	CommitSHA   string // Set for findings in a commit message instead of a file
+	// Verification is "active" or "inactive" when the secret was checked
+	// against its provider, and empty otherwise
+	Verification string
+}
+
+// Line describes where in its file an issue is, e.g. "12" or "12 (cell 3)"
+func (i SecurityIssue) Line() string {
+	if i.Cell > 0 {
+		return fmt.Sprintf("%d (cell %d)", i.LineNumber, i.Cell)
+	}
+	return strconv.Itoa(i.LineNumber)
+}
+
+// VerificationNote describes the result of checking an issue's secret with
+// its provider, e.g. " (verified active)", or returns "" when it wasn't
+// checked
+func (i SecurityIssue) VerificationNote() string {
+	switch i.Verification {
+	case "active":
+		return " (verified active)"
+	case "inactive":
+		return " (likely inactive)"
+	}
+	return ""
+}
+
+// DigestEntry is an open PR with findings waiting for the next digest
+type DigestEntry struct {
+	Repository  Repository
+	PullRequest PullRequest
+	Issues      []SecurityIssue
+	UpdatedAt   time.Time
 }
 
 // ReviewContext contains all info needed for a review
@@ -78,6 +235,56 @@ type ReviewContext struct {
 	PullRequest PullRequest
 	DiffFiles   []DiffFile
 	ScanResult  ScanResult
+	// UnreviewedFiles counts the files left out of DiffFiles by the
+	// REVIEW_MAX_FILES cap
+	UnreviewedFiles int
+	// AuthorHistory summarizes the author's earlier findings; nil when
+	// history isn't tracked
+	AuthorHistory *AuthorHistory
+	// Fresh ignores cached AI reviews, for reviews requested explicitly
+	Fresh bool
+}
+
+// AuthorHistory summarizes an author's findings in earlier pull requests
+type AuthorHistory struct {
+	PriorPRs      int
+	PriorFindings int
+}
+
+// ReviewResult is the outcome of an AI review of a PR's files
+type ReviewResult struct {
+	ReviewText string
+	Reviewed   int      // Files reviewed, including cache hits
+	Failed     []string // Files whose review failed
+	Skipped    int      // Files left out by the file budget
+	// Verdict is the most severe per-file verdict: "approve", "comment"
+	// or "request-changes". It is empty unless verdicts were requested.
+	Verdict string
+	// Comments are the line-specific findings, when inline comments were
+	// requested
+	Comments []ReviewComment
+}
+
+// ReviewComment is an AI finding about a single line of a file
+type ReviewComment struct {
+	Path string
+	Line int // line in the new version of the file
+	Body string
+}
+
+// Review is a formal PR review
+type Review struct {
+	Event     string // APPROVE, REQUEST_CHANGES or COMMENT; empty once dismissed
+	CommitSHA string
+}
+
+// FailedPercent returns the share of attempted files whose review failed
+func (r ReviewResult) FailedPercent() int {
+	attempted := r.Reviewed + len(r.Failed)
+	if attempted == 0 {
+		return 0
+	}
+	return len(r.Failed) * 100 / attempted
 }
 
 // SlackMessage represents the structure we'll send to Slack
@@ -87,4 +294,4 @@ type SlackMessage struct {
 	Blocks      interface{} // Slack Block Kit blocks
 	ThreadTS    string      // For threading messages
 	UnfurlLinks bool
-}
\ No newline at end of file
+}
@@ -0,0 +1,39 @@
+// Package pathmatch matches file paths against the path patterns used
+// throughout the configuration: project paths, ignore and exclude paths,
+// severity rules and review priorities.
+package pathmatch
+
+import (
+	"path"
+	"strings"
+)
+
+// Match reports whether filename matches pattern. A pattern ending in "/"
+// matches everything under that directory; other patterns are globs
+// matched against the full path and the base name.
+func Match(pattern, filename string) bool {
+	if strings.HasSuffix(pattern, "/") {
+		return strings.HasPrefix(filename, pattern)
+	}
+	if ok, _ := path.Match(pattern, filename); ok {
+		return true
+	}
+	ok, _ := path.Match(pattern, path.Base(filename))
+	return ok
+}
+
+// Index returns the index of the first of patterns matching filename, or
+// -1 if none match
+func Index(patterns []string, filename string) int {
+	for i, pattern := range patterns {
+		if Match(pattern, filename) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Any reports whether filename matches one of patterns
+func Any(patterns []string, filename string) bool {
+	return Index(patterns, filename) >= 0
+}
@@ -0,0 +1,74 @@
+package policy
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+)
+
+// LoadFunc fetches the raw policy file
+type LoadFunc func(ctx context.Context) ([]byte, error)
+
+// Cache holds the organization policy and reloads it once it is older
+// than the TTL. If a reload fails the last good policy stays in use.
+type Cache struct {
+	load LoadFunc
+	ttl  time.Duration
+
+	mu       sync.Mutex
+	policy   *Policy
+	loadedAt time.Time
+}
+
+// NewCache creates a policy cache that reloads through load every ttl
+func NewCache(load LoadFunc, ttl time.Duration) *Cache {
+	return &Cache{
+		load: load,
+		ttl:  ttl,
+	}
+}
+
+// Get returns the current policy, reloading it if the TTL has expired.
+// A nil Cache returns a nil Policy, which applies no rules.
+func (c *Cache) Get(ctx context.Context) *Policy {
+	if c == nil {
+		return nil
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.loadedAt.IsZero() && time.Since(c.loadedAt) < c.ttl {
+		return c.policy
+	}
+
+	// Retry failed loads after a full TTL rather than on every event
+	c.loadedAt = time.Now()
+
+	data, err := c.load(ctx)
+	if err != nil {
+		log.Printf("Failed to load org policy, keeping previous: %v", err)
+		return c.policy
+	}
+
+	p, err := Parse(data)
+	if err != nil {
+		log.Printf("Invalid org policy, keeping previous: %v", err)
+		return c.policy
+	}
+
+	c.policy = p
+	return c.policy
+}
+
+// Invalidate forces the next Get to reload the policy
+func (c *Cache) Invalidate() {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.loadedAt = time.Time{}
+}
@@ -0,0 +1,107 @@
+package policy
+
+import (
+	"fmt"
+	"path"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/pathmatch"
+	"gopkg.in/yaml.v3"
+)
+
+// Policy is an organization-wide scanning policy managed in one place by
+// the security team. A nil Policy applies no rules.
+type Policy struct {
+	// BlockSeverity is the default minimum severity that blocks a PR
+	BlockSeverity string `yaml:"block_severity"`
+
+	// AllowPatterns lists pattern names that are never reported
+	AllowPatterns []string `yaml:"allow_patterns"`
+
+	// AllowFingerprints lists individual findings that are never reported
+	AllowFingerprints []string `yaml:"allow_fingerprints"`
+
+	// ExcludePaths lists paths whose findings are ignored. A pattern
+	// ending in "/" matches everything under that directory; other
+	// patterns are globs matched against the full path and the base name.
+	ExcludePaths []string `yaml:"exclude_paths"`
+}
+
+// validSeverities lists the severities accepted by block_severity
+var validSeverities = map[string]bool{
+	"CRITICAL": true,
+	"HIGH":     true,
+	"MEDIUM":   true,
+	"LOW":      true,
+}
+
+// Parse parses a YAML policy file
+func Parse(data []byte) (*Policy, error) {
+	var p Policy
+	if err := yaml.Unmarshal(data, &p); err != nil {
+		return nil, fmt.Errorf("failed to parse policy: %w", err)
+	}
+
+	p.BlockSeverity = strings.ToUpper(p.BlockSeverity)
+	if p.BlockSeverity != "" && !validSeverities[p.BlockSeverity] {
+		return nil, fmt.Errorf("invalid block_severity %q in policy", p.BlockSeverity)
+	}
+	for _, pattern := range p.ExcludePaths {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return nil, fmt.Errorf("invalid exclude_paths pattern %q: %w", pattern, err)
+		}
+	}
+
+	return &p, nil
+}
+
+// BlockThreshold returns the policy's block severity, or fallback when
+// the policy doesn't set one
+func (p *Policy) BlockThreshold(fallback string) string {
+	if p == nil || p.BlockSeverity == "" {
+		return fallback
+	}
+	return p.BlockSeverity
+}
+
+// Apply removes the findings the policy allows or excludes from result
+func (p *Policy) Apply(result models.ScanResult) models.ScanResult {
+	if p == nil {
+		return result
+	}
+
+	var kept []models.SecurityIssue
+	for _, issue := range result.Issues {
+		if !p.ignores(issue) {
+			kept = append(kept, issue)
+		}
+	}
+
+	result.Issues = kept
+	result.Found = len(kept) > 0
+	return result
+}
+
+// ignores reports whether the policy suppresses issue
+func (p *Policy) ignores(issue models.SecurityIssue) bool {
+	for _, name := range p.AllowPatterns {
+		if strings.EqualFold(name, issue.Pattern) {
+			return true
+		}
+	}
+	for _, fingerprint := range p.AllowFingerprints {
+		if fingerprint == issue.Fingerprint {
+			return true
+		}
+	}
+	return p.Excluded(issue.FilePath)
+}
+
+// Excluded reports whether filename matches one of the excluded paths
+func (p *Policy) Excluded(filename string) bool {
+	if p == nil {
+		return false
+	}
+	return pathmatch.Any(p.ExcludePaths, filename)
+}
@@ -0,0 +1,69 @@
+package scanner
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// annotationLevels maps finding severities to GitHub Actions annotation
+// commands
+var annotationLevels = map[string]string{
+	"CRITICAL": "error",
+	"HIGH":     "error",
+	"MEDIUM":   "warning",
+	"LOW":      "notice",
+}
+
+// annotationData escapes an annotation message
+var annotationData = strings.NewReplacer(
+	"%", "%25",
+	"\r", "%0D",
+	"\n", "%0A",
+)
+
+// annotationProperty escapes an annotation property value, which also
+// can't contain the property separators
+var annotationProperty = strings.NewReplacer(
+	"%", "%25",
+	"\r", "%0D",
+	"\n", "%0A",
+	":", "%3A",
+	",", "%2C",
+)
+
+// Annotation formats issue as a GitHub Actions workflow command, e.g.
+// "::error file=app.go,line=3,title=AWS Access Key::[CRITICAL] ...", so
+// it shows as an annotation when printed from a workflow step
+func Annotation(issue models.SecurityIssue) string {
+	level, ok := annotationLevels[issue.Severity]
+	if !ok {
+		level = "warning"
+	}
+
+	var props []string
+	if issue.FilePath != "" {
+		props = append(props, "file="+annotationProperty.Replace(issue.FilePath))
+		props = append(props, fmt.Sprintf("line=%d", issue.LineNumber))
+	}
+	props = append(props, "title="+annotationProperty.Replace(issue.Type))
+
+	message := fmt.Sprintf("[%s] %s (%s)", issue.Severity, issue.Description, issue.Fingerprint)
+	if issue.CommitSHA != "" {
+		message = fmt.Sprintf("[%s] %s in commit message %s (%s)", issue.Severity, issue.Description, issue.CommitSHA, issue.Fingerprint)
+	}
+
+	return fmt.Sprintf("::%s %s::%s", level, strings.Join(props, ","), annotationData.Replace(message))
+}
+
+// WriteAnnotations writes one annotation per issue to w
+func WriteAnnotations(w io.Writer, issues []models.SecurityIssue) error {
+	for _, issue := range issues {
+		if _, err := fmt.Fprintln(w, Annotation(issue)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
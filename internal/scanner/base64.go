@@ -0,0 +1,89 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"regexp"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/Rishav176/GitReviewed/internal/diff"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// maxBase64Decode caps the size of a base64 string that is decoded, so
+// large embedded images aren't decoded just to be discarded
+const maxBase64Decode = 64 * 1024
+
+// base64Run matches a run of base64 long enough to hide a secret
+var base64Run = regexp.MustCompile(`[A-Za-z0-9+/]{40,}={0,2}`)
+
+// scanBase64 decodes the base64 runs in the added lines of a diff, such as
+// a kubeconfig's client-key-data, and scans the decoded text. Findings
+// keep the line in the diff the run was on and note the encoding in their
+// description.
+func scanBase64(patterns []SecretPattern, lines []diff.Line, filename string, values map[string]string) []models.SecurityIssue {
+	var decoded []diff.Line
+	var origins []int
+	for _, line := range lines {
+		if line.Kind != diff.Added || ShouldIgnoreLine(line.Text) {
+			continue
+		}
+		for _, text := range decodeBase64Text(line.Text) {
+			decoded = appendDecoded(decoded, text)
+			origins = append(origins, line.Index)
+		}
+	}
+	if len(decoded) == 0 {
+		return nil
+	}
+
+	issues := scanLines(patterns, decoded, filename, values)
+	for i := range issues {
+		issues[i].LineNumber = origins[issues[i].LineNumber-1]
+		issues[i].StartColumn, issues[i].EndColumn = 0, 0
+		issues[i].Description += " (base64-encoded)"
+	}
+	return issues
+}
+
+// appendDecoded appends text decoded from the patch to lines as the next
+// added line, so decoded text is scanned like the patch itself
+func appendDecoded(lines []diff.Line, text string) []diff.Line {
+	return append(lines, diff.Line{Kind: diff.Added, Text: text, Index: len(lines) + 1})
+}
+
+// decodeBase64Text decodes the base64 runs in text that hold printable
+// text, such as a credentials file attached as an output, and returns
+// their lines
+func decodeBase64Text(text string) []string {
+	var lines []string
+	for _, run := range base64Run.FindAllString(text, -1) {
+		if len(run) > maxBase64Decode {
+			continue
+		}
+		data, err := base64.StdEncoding.DecodeString(run)
+		if err != nil {
+			data, err = base64.RawStdEncoding.DecodeString(strings.TrimRight(run, "="))
+		}
+		if err != nil || !printable(data) {
+			continue
+		}
+		lines = append(lines, strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")...)
+	}
+	return lines
+}
+
+// printable reports whether data is UTF-8 text without control characters
+// other than whitespace
+func printable(data []byte) bool {
+	if !utf8.Valid(data) {
+		return false
+	}
+	for _, r := range string(data) {
+		if !unicode.IsPrint(r) && !unicode.IsSpace(r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,110 @@
+package scanner
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// Baseline is a set of known findings that should not be reported again,
+// used to adopt the scanner on repos that already contain secrets
+type Baseline struct {
+	Findings []BaselineEntry `json:"findings"`
+
+	index map[string]bool
+}
+
+// BaselineEntry records a single known finding. Only the fingerprint is
+// used for matching; pattern and file are kept for humans reading the file.
+type BaselineEntry struct {
+	Fingerprint string `json:"fingerprint"`
+	Pattern     string `json:"pattern"`
+	File        string `json:"file"`
+}
+
+// Fingerprint computes a stable identifier for a finding. It is the first
+// 16 bytes, hex encoded, of the SHA-256 of the pattern name, file path and
+// matched value joined by NUL bytes. The matched value is trimmed of
+// surrounding whitespace and quotes. Line numbers are deliberately left out
+// so that fingerprints survive unrelated edits elsewhere in the file.
+func Fingerprint(pattern, filePath, value string) string {
+	value = strings.Trim(value, " \t'\"`")
+	sum := sha256.Sum256([]byte(pattern + "\x00" + filePath + "\x00" + value))
+	return hex.EncodeToString(sum[:16])
+}
+
+// NewBaseline creates a baseline containing the given issues
+func NewBaseline(issues []models.SecurityIssue) *Baseline {
+	b := &Baseline{Findings: []BaselineEntry{}}
+	seen := make(map[string]bool)
+	for _, issue := range issues {
+		if seen[issue.Fingerprint] {
+			continue
+		}
+		seen[issue.Fingerprint] = true
+		b.Findings = append(b.Findings, BaselineEntry{
+			Fingerprint: issue.Fingerprint,
+			Pattern:     issue.Pattern,
+			File:        issue.FilePath,
+		})
+	}
+	b.index = seen
+	return b
+}
+
+// LoadBaseline reads a baseline file. An empty path returns a nil baseline.
+func LoadBaseline(path string) (*Baseline, error) {
+	if path == "" {
+		return nil, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read baseline file: %w", err)
+	}
+
+	var b Baseline
+	if err := json.Unmarshal(data, &b); err != nil {
+		return nil, fmt.Errorf("failed to parse baseline file: %w", err)
+	}
+
+	b.index = make(map[string]bool, len(b.Findings))
+	for _, entry := range b.Findings {
+		b.index[entry.Fingerprint] = true
+	}
+
+	return &b, nil
+}
+
+// Write encodes the baseline as indented JSON
+func (b *Baseline) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(b)
+}
+
+// Contains reports whether a fingerprint is in the baseline
+func (b *Baseline) Contains(fingerprint string) bool {
+	return b != nil && b.index[fingerprint]
+}
+
+// Filter returns the issues that are not in the baseline
+func (b *Baseline) Filter(issues []models.SecurityIssue) []models.SecurityIssue {
+	if b == nil || len(b.index) == 0 {
+		return issues
+	}
+
+	var kept []models.SecurityIssue
+	for _, issue := range issues {
+		if !b.Contains(issue.Fingerprint) {
+			kept = append(kept, issue)
+		}
+	}
+	return kept
+}
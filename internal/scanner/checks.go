@@ -0,0 +1,102 @@
+package scanner
+
+import (
+	"encoding/base64"
+	"math"
+	"net/url"
+	"strings"
+	"unicode"
+)
+
+// connectionStringSchemes are covered by the Database Connection String
+// pattern, so the generic URL pattern skips them
+var connectionStringSchemes = map[string]bool{
+	"mysql":    true,
+	"postgres": true,
+	"mongodb":  true,
+	"redis":    true,
+}
+
+// placeholderPassword reports whether password is a template variable or
+// stand-in rather than a real credential
+func placeholderPassword(password string) bool {
+	if password == "" || strings.ContainsAny(password, "${}<>%") {
+		return true
+	}
+	if strings.Trim(password, "*xX.") == "" {
+		return true
+	}
+	switch strings.ToLower(password) {
+	case "pass", "password", "passwd", "secret", "changeme", "pwd":
+		return true
+	}
+	return false
+}
+
+// checkURLCredentials confirms a URL match carries a real-looking username
+// and password
+func checkURLCredentials(value string) bool {
+	u, err := url.Parse(value)
+	if err != nil || u.User == nil || connectionStringSchemes[strings.ToLower(u.Scheme)] {
+		return false
+	}
+	password, ok := u.User.Password()
+	if !ok || u.User.Username() == "" || placeholderPassword(password) {
+		return false
+	}
+
+	// "host:8080@..." parses as a password of 8080 but is a port
+	if len(password) <= 5 && strings.Trim(password, "0123456789") == "" {
+		return false
+	}
+	return true
+}
+
+// checkBasicAuth confirms a Basic authorization value decodes to a
+// "user:password" pair
+func checkBasicAuth(value string) bool {
+	decoded, err := base64.StdEncoding.DecodeString(value)
+	if err != nil || !printable(decoded) {
+		return false
+	}
+	user, password, ok := strings.Cut(string(decoded), ":")
+	return ok && user != "" && !strings.ContainsAny(user, " \t\r\n") && !placeholderPassword(password)
+}
+
+// minEnvSecretEntropy is the least Shannon entropy, in bits per character,
+// of a value reported by the Environment Variable Secret pattern
+const minEnvSecretEntropy = 3.0
+
+// checkEnvSecret confirms an unquoted environment value looks like a
+// secret: mixed letters and digits with enough entropy, and not a path,
+// URL or stand-in
+func checkEnvSecret(value string) bool {
+	if placeholderPassword(value) || strings.Contains(value, "://") || strings.HasPrefix(value, "/") ||
+		strings.HasPrefix(value, "./") || strings.HasPrefix(value, "~") {
+		return false
+	}
+	if !strings.ContainsAny(value, "0123456789") || strings.IndexFunc(value, unicode.IsLetter) < 0 {
+		return false
+	}
+	return shannonEntropy(value) >= minEnvSecretEntropy
+}
+
+// shannonEntropy returns the Shannon entropy of s in bits per character
+func shannonEntropy(s string) float64 {
+	if s == "" {
+		return 0
+	}
+	counts := make(map[rune]int)
+	total := 0
+	for _, r := range s {
+		counts[r]++
+		total++
+	}
+
+	entropy := 0.0
+	for _, n := range counts {
+		p := float64(n) / float64(total)
+		entropy -= p * math.Log2(p)
+	}
+	return entropy
+}
@@ -0,0 +1,152 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// corpusFile is the default name lines of a corpus are scanned under
+const corpusFile = "corpus.txt"
+
+// Corpus is a set of labeled lines for measuring how well the patterns
+// detect secrets, along with the minimum scores they must reach
+type Corpus struct {
+	MinPrecision float64      `yaml:"min_precision"`
+	MinRecall    float64      `yaml:"min_recall"`
+	Cases        []CorpusCase `yaml:"cases"`
+}
+
+// CorpusCase is a single labeled line, or a few consecutive lines for
+// patterns that span them. Pattern names the pattern expected to report
+// it, and is empty for lines that must not be reported.
+type CorpusCase struct {
+	Line    string `yaml:"line"`
+	Pattern string `yaml:"pattern"`
+	// File is the name the line is scanned under, for patterns and
+	// checks that depend on it
+	File string `yaml:"file"`
+}
+
+// PatternScore counts one pattern's results over a corpus
+type PatternScore struct {
+	Pattern        string
+	TruePositives  int
+	FalsePositives int
+	FalseNegatives int
+}
+
+// CorpusReport is the result of scanning a corpus
+type CorpusReport struct {
+	// Patterns holds the score of each pattern with a labeled line or a
+	// finding, sorted by name
+	Patterns []PatternScore
+	// Misses are the cases reported wrongly: labeled lines their pattern
+	// didn't report and unlabeled lines that were reported
+	Misses []CorpusCase
+
+	TruePositives  int
+	FalsePositives int
+	FalseNegatives int
+}
+
+// Precision is the share of findings that were expected, and 1 when
+// nothing was found
+func (r CorpusReport) Precision() float64 {
+	found := r.TruePositives + r.FalsePositives
+	if found == 0 {
+		return 1
+	}
+	return float64(r.TruePositives) / float64(found)
+}
+
+// Recall is the share of labeled lines their pattern reported, and 1 when
+// no line is labeled
+func (r CorpusReport) Recall() float64 {
+	expected := r.TruePositives + r.FalseNegatives
+	if expected == 0 {
+		return 1
+	}
+	return float64(r.TruePositives) / float64(expected)
+}
+
+// LoadCorpus reads a YAML corpus file
+func LoadCorpus(path string) (*Corpus, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read corpus: %w", err)
+	}
+
+	var corpus Corpus
+	if err := yaml.Unmarshal(data, &corpus); err != nil {
+		return nil, fmt.Errorf("failed to parse corpus: %w", err)
+	}
+
+	if corpus.MinPrecision < 0 || corpus.MinPrecision > 1 || corpus.MinRecall < 0 || corpus.MinRecall > 1 {
+		return nil, fmt.Errorf("min_precision and min_recall must be between 0 and 1")
+	}
+	for i, c := range corpus.Cases {
+		if c.Line == "" {
+			return nil, fmt.Errorf("corpus case %d has no line", i+1)
+		}
+	}
+	return &corpus, nil
+}
+
+// EvaluateCorpus scans each case's line on its own and scores the
+// findings. A labeled line counts as a true positive when its pattern
+// reports it and a false negative otherwise; other patterns firing on it
+// aren't counted, since the line holds a secret either way. Every finding
+// on an unlabeled line is a false positive.
+func (s *Scanner) EvaluateCorpus(cases []CorpusCase) CorpusReport {
+	scores := make(map[string]*PatternScore)
+	score := func(pattern string) *PatternScore {
+		if scores[pattern] == nil {
+			scores[pattern] = &PatternScore{Pattern: pattern}
+		}
+		return scores[pattern]
+	}
+
+	var report CorpusReport
+	for _, c := range cases {
+		file := c.File
+		if file == "" {
+			file = corpusFile
+		}
+
+		found := make(map[string]bool)
+		for _, issue := range s.ScanDiff(ContentAsDiff(c.Line), file) {
+			found[issue.Type] = true
+		}
+
+		if c.Pattern != "" {
+			if found[c.Pattern] {
+				score(c.Pattern).TruePositives++
+				report.TruePositives++
+			} else {
+				score(c.Pattern).FalseNegatives++
+				report.FalseNegatives++
+				report.Misses = append(report.Misses, c)
+			}
+			continue
+		}
+
+		for pattern := range found {
+			score(pattern).FalsePositives++
+			report.FalsePositives++
+		}
+		if len(found) > 0 {
+			report.Misses = append(report.Misses, c)
+		}
+	}
+
+	for _, ps := range scores {
+		report.Patterns = append(report.Patterns, *ps)
+	}
+	sort.Slice(report.Patterns, func(i, j int) bool {
+		return report.Patterns[i].Pattern < report.Patterns[j].Pattern
+	})
+	return report
+}
@@ -0,0 +1,54 @@
+package scanner
+
+import "testing"
+
+// corpusPath is the bundled corpus, relative to this package
+const corpusPath = "../../configs/corpus.yaml"
+
+func TestCorpusMeetsThresholds(t *testing.T) {
+	corpus, err := LoadCorpus(corpusPath)
+	if err != nil {
+		t.Fatalf("LoadCorpus: %v", err)
+	}
+	patterns, err := LoadPatterns("")
+	if err != nil {
+		t.Fatalf("LoadPatterns: %v", err)
+	}
+
+	report := NewScannerWithPatterns(patterns).EvaluateCorpus(corpus.Cases)
+	for _, c := range report.Misses {
+		if c.Pattern != "" {
+			t.Logf("missed (%s): %s", c.Pattern, Redact(c.Line))
+		} else {
+			t.Logf("unexpected finding: %s", Redact(c.Line))
+		}
+	}
+
+	if precision := report.Precision(); precision < corpus.MinPrecision {
+		t.Errorf("precision %.3f is below min_precision %.3f", precision, corpus.MinPrecision)
+	}
+	if recall := report.Recall(); recall < corpus.MinRecall {
+		t.Errorf("recall %.3f is below min_recall %.3f", recall, corpus.MinRecall)
+	}
+}
+
+func TestEvaluateCorpusScoresCases(t *testing.T) {
+	cases := []CorpusCase{
+		{Pattern: "GitHub Personal Access Token", Line: `token = "` + testToken + `"`},
+		{Pattern: "GitHub Personal Access Token", Line: `token = os.Getenv("GITHUB_TOKEN")`},
+		{Line: `gh = "` + otherTestToken + `"`},
+		{Line: `const prefix = "ghp_"`},
+	}
+
+	report := NewScanner().EvaluateCorpus(cases)
+	if report.TruePositives != 1 || report.FalseNegatives != 1 || report.FalsePositives == 0 {
+		t.Errorf("got %d TP, %d FN, %d FP; want 1 TP, 1 FN and some FP",
+			report.TruePositives, report.FalseNegatives, report.FalsePositives)
+	}
+	if len(report.Misses) != 2 || report.Misses[0] != cases[1] || report.Misses[1] != cases[2] {
+		t.Errorf("misses = %+v, want cases 2 and 3", report.Misses)
+	}
+	if recall := report.Recall(); recall != 0.5 {
+		t.Errorf("recall = %v, want 0.5", recall)
+	}
+}
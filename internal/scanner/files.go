@@ -0,0 +1,192 @@
+package scanner
+
+import (
+	"path"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// SensitiveFilePattern is the pattern name of findings for committed
+// credential files. It can be disabled or allowlisted like any pattern.
+const SensitiveFilePattern = "Sensitive File"
+
+// DefaultSensitiveFileSeverity is the severity of sensitive file findings
+// unless configured otherwise
+const DefaultSensitiveFileSeverity = "HIGH"
+
+// KeyFilePattern is the pattern name of findings for committed key and
+// certificate files. It can be disabled or allowlisted like any pattern.
+const KeyFilePattern = "Key File"
+
+// keyFileSeverity is the severity of key file findings; the file itself
+// is the key material, whether or not its content can be scanned
+const keyFileSeverity = "CRITICAL"
+
+// DefaultSkipExtensions are file extensions that are never scanned unless
+// configured otherwise: images, fonts, documents, archives, media and
+// source maps, whose content only produces garbage matches
+var DefaultSkipExtensions = []string{
+	".png", ".jpg", ".jpeg", ".gif", ".bmp", ".ico", ".webp",
+	".woff", ".woff2", ".ttf", ".otf", ".eot",
+	".pdf",
+	".zip", ".gz", ".tgz", ".jar",
+	".mp3", ".mp4", ".mov", ".wasm",
+	".map",
+}
+
+// NormalizeExtensions lowercases extensions and adds a missing leading
+// dot, so "PNG" and ".png" are the same
+func NormalizeExtensions(extensions []string) []string {
+	normalized := make([]string, 0, len(extensions))
+	for _, ext := range extensions {
+		ext = strings.ToLower(strings.TrimSpace(ext))
+		if ext == "" {
+			continue
+		}
+		if !strings.HasPrefix(ext, ".") {
+			ext = "." + ext
+		}
+		normalized = append(normalized, ext)
+	}
+	return normalized
+}
+
+// skippedExtension reports whether filename ends in one of the normalized
+// extensions. Suffixes with several dots, such as ".min.js", work too.
+func skippedExtension(filename string, extensions []string) bool {
+	name := strings.ToLower(path.Base(filename))
+	for _, ext := range extensions {
+		if strings.HasSuffix(name, ext) {
+			return true
+		}
+	}
+	return false
+}
+
+// keyFileExtensions mark files holding private keys or certificate bundles
+var keyFileExtensions = []string{".pem", ".key", ".pfx", ".p12"}
+
+// sampleMarkers in a key file's base name mark keys generated for tests or
+// documentation, which are never flagged
+var sampleMarkers = []string{"example", "sample", "dummy", "fake", "mock"}
+
+// sensitiveFileNames are globs matched against a file's base name. Such
+// files usually hold real credentials even when no line matches a pattern.
+var sensitiveFileNames = []string{
+	".env",
+	".env.*",
+	"*.env",
+	"credentials",
+	"credentials.*",
+	".git-credentials",
+	".netrc",
+	".npmrc",
+	".pypirc",
+	".htpasswd",
+	"secrets.yml",
+	"secrets.yaml",
+	"secrets.json",
+	"id_rsa",
+	"id_dsa",
+	"id_ecdsa",
+	"id_ed25519",
+}
+
+// templateSuffixes mark intentional templates such as .env.example, which
+// are never flagged
+var templateSuffixes = []string{
+	".example",
+	".sample",
+	".template",
+	".tmpl",
+	".dist",
+	".defaults",
+}
+
+// SensitiveFile reports whether filename looks like a committed credentials
+// file
+func SensitiveFile(filename string) bool {
+	base := strings.ToLower(path.Base(filename))
+	if isTemplate(base) {
+		return false
+	}
+
+	for _, pattern := range sensitiveFileNames {
+		if ok, _ := path.Match(pattern, base); ok {
+			return true
+		}
+	}
+	return false
+}
+
+// KeyFile reports whether filename looks like a committed private key or
+// certificate bundle. Templates, sample keys and files under a testdata
+// directory are not flagged.
+func KeyFile(filename string) bool {
+	base := strings.ToLower(path.Base(filename))
+	if isTemplate(base) || strings.HasPrefix(filename, "testdata/") || strings.Contains(filename, "/testdata/") {
+		return false
+	}
+	for _, marker := range sampleMarkers {
+		if strings.Contains(base, marker) {
+			return false
+		}
+	}
+
+	for _, ext := range keyFileExtensions {
+		if path.Ext(base) == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// isTemplate reports whether base is the name of an intentional template
+func isTemplate(base string) bool {
+	for _, suffix := range templateSuffixes {
+		if strings.HasSuffix(base, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// scanKeyFile returns a finding for file if it is a key file that was
+// added or modified, or nil otherwise. Binary files without a patch are
+// flagged too.
+func scanKeyFile(file models.DiffFile) []models.SecurityIssue {
+	if file.Status == "removed" || !KeyFile(file.Filename) {
+		return nil
+	}
+
+	return []models.SecurityIssue{{
+		Type:        KeyFilePattern,
+		FilePath:    file.Filename,
+		LineNumber:  1,
+		Severity:    keyFileSeverity,
+		Description: "Key or certificate file committed; it likely holds private key material",
+		Remediation: "Revoke and reissue the key or certificate, delete the file from the branch and add it to .gitignore",
+		Pattern:     KeyFilePattern,
+		Fingerprint: Fingerprint(KeyFilePattern, file.Filename, ""),
+	}}
+}
+
+// scanSensitiveFile returns a finding for file if it is a credentials file
+// that was added or modified, or nil otherwise
+func scanSensitiveFile(file models.DiffFile, severity string) []models.SecurityIssue {
+	if severity == "" || file.Status == "removed" || !SensitiveFile(file.Filename) {
+		return nil
+	}
+
+	return []models.SecurityIssue{{
+		Type:        SensitiveFilePattern,
+		FilePath:    file.Filename,
+		LineNumber:  1,
+		Severity:    severity,
+		Description: "Credentials file committed; files like this usually hold real secrets",
+		Remediation: "Rotate the credentials in the file, delete it from the branch and add it to .gitignore",
+		Pattern:     SensitiveFilePattern,
+		Fingerprint: Fingerprint(SensitiveFilePattern, file.Filename, ""),
+	}}
+}
@@ -0,0 +1,38 @@
+package scanner
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+func TestScanFilesIgnoresSkippedExtensions(t *testing.T) {
+	// Image bytes can decode to anything, including a token's shape
+	patch := "@@ -0,0 +1,2 @@\n+\x89PNG\n+IDAT token=\"" + testToken + "\"\n"
+	files := []models.DiffFile{
+		{Filename: "docs/logo.png", Status: "added", Additions: 2, Changes: 2, Patch: patch},
+		{Filename: "assets/LOGO.PNG", Status: "added", Additions: 2, Changes: 2, Patch: patch},
+	}
+
+	if result := NewScanner().ScanFiles(files); result.Found || len(result.Issues) != 0 {
+		t.Errorf("got findings in skipped files: %+v", result.Issues)
+	}
+}
+
+func TestScanFilesScansExtensionsNoLongerSkipped(t *testing.T) {
+	patch := "@@ -0,0 +1,1 @@\n+token=\"" + testToken + "\"\n"
+	files := []models.DiffFile{{Filename: "docs/logo.png", Status: "added", Additions: 1, Changes: 1, Patch: patch}}
+
+	s := NewScanner()
+	s.SetSkipExtensions([]string{"jpg"})
+	findingFor(t, s.ScanFiles(files).Issues, "GitHub Personal Access Token")
+}
+
+func TestNormalizeExtensions(t *testing.T) {
+	got := NormalizeExtensions([]string{"PNG", " .Map ", "", "min.js"})
+	want := []string{".png", ".map", ".min.js"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("NormalizeExtensions() = %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,73 @@
+package scanner
+
+import (
+	"encoding/json"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// FindingsSchemaVersion is the version of the JSON written by
+// MarshalFindings. It only changes when fields are renamed, removed or
+// change meaning; new optional fields keep the version.
+const FindingsSchemaVersion = 1
+
+// FindingsReport is the stable JSON form of a scan result for editor
+// plugins and dashboards. It never contains unredacted secrets.
+type FindingsReport struct {
+	SchemaVersion  int       `json:"schema_version"`
+	TotalFiles     int       `json:"total_files"`
+	UnscannedFiles []string  `json:"unscanned_files"`
+	Findings       []Finding `json:"findings"`
+}
+
+// Finding is a single finding in a FindingsReport. Line is the line in the
+// scanned diff, which is the file line for content scanned with
+// ContentAsDiff. Columns are 1-based and inclusive, and 0 when unknown.
+type Finding struct {
+	Fingerprint string `json:"fingerprint"`
+	Pattern     string `json:"pattern"`
+	Severity    string `json:"severity"`
+	Description string `json:"description"`
+	File        string `json:"file,omitempty"`
+	Commit      string `json:"commit,omitempty"`
+	Line        int    `json:"line"`
+	Cell        int    `json:"cell,omitempty"`
+	StartColumn int    `json:"start_column"`
+	EndColumn   int    `json:"end_column"`
+	Match       string `json:"match"`
+}
+
+// NewFindingsReport converts result to its stable JSON form
+func NewFindingsReport(result models.ScanResult) FindingsReport {
+	report := FindingsReport{
+		SchemaVersion:  FindingsSchemaVersion,
+		TotalFiles:     result.TotalFiles,
+		UnscannedFiles: result.UnscannedFiles,
+		Findings:       make([]Finding, 0, len(result.Issues)),
+	}
+	if report.UnscannedFiles == nil {
+		report.UnscannedFiles = []string{}
+	}
+
+	for _, issue := range result.Issues {
+		report.Findings = append(report.Findings, Finding{
+			Fingerprint: issue.Fingerprint,
+			Pattern:     issue.Pattern,
+			Severity:    issue.Severity,
+			Description: issue.Description,
+			File:        issue.FilePath,
+			Commit:      issue.CommitSHA,
+			Line:        issue.LineNumber,
+			Cell:        issue.Cell,
+			StartColumn: issue.StartColumn,
+			EndColumn:   issue.EndColumn,
+			Match:       issue.Match,
+		})
+	}
+	return report
+}
+
+// MarshalFindings encodes result as an indented FindingsReport
+func MarshalFindings(result models.ScanResult) ([]byte, error) {
+	return json.MarshalIndent(NewFindingsReport(result), "", "  ")
+}
@@ -0,0 +1,75 @@
+package scanner
+
+import (
+	"bytes"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// update rewrites golden files with the current output instead of
+// comparing against them
+var update = flag.Bool("update", false, "update golden files")
+
+func TestMarshalFindingsGolden(t *testing.T) {
+	patch := "@@ -3,1 +3,3 @@ func main() {\n" +
+		" \tclient := github.NewClient(nil)\n" +
+		"+\ttoken := \"" + testToken + "\"\n" +
+		"+\tclient = client.WithAuthToken(token)\n"
+	issues := NewScanner().ScanDiff(patch, "cmd/main.go")
+	issues = append(issues, models.SecurityIssue{
+		Type:        "AWS Access Key ID",
+		Pattern:     "AWS Access Key ID",
+		Severity:    "CRITICAL",
+		Description: "AWS Access Key ID detected",
+		CommitSHA:   "0123456789abcdef0123456789abcdef01234567",
+		LineNumber:  2,
+		Match:       Redact("AKIAJ3R6WC76CC30TOCG"),
+		Fingerprint: Fingerprint("AWS Access Key ID", "", "AKIAJ3R6WC76CC30TOCG"),
+	})
+
+	got, err := MarshalFindings(models.ScanResult{
+		Found:          true,
+		Issues:         issues,
+		TotalFiles:     2,
+		UnscannedFiles: []string{"assets/bundle.min.js"},
+	})
+	if err != nil {
+		t.Fatalf("MarshalFindings: %v", err)
+	}
+	got = append(got, '\n')
+
+	golden := filepath.Join("testdata", "findings.golden.json")
+	if *update {
+		if err := os.WriteFile(golden, got, 0o644); err != nil {
+			t.Fatalf("failed to update golden file: %v", err)
+		}
+	}
+	want, err := os.ReadFile(golden)
+	if err != nil {
+		t.Fatalf("failed to read golden file: %v", err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Errorf("MarshalFindings output changed; if the change is intended, bump FindingsSchemaVersion when fields are renamed, removed or change meaning, then run go test -update\ngot:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+func TestMarshalFindingsEmpty(t *testing.T) {
+	got, err := MarshalFindings(models.ScanResult{})
+	if err != nil {
+		t.Fatalf("MarshalFindings: %v", err)
+	}
+
+	want := `{
+  "schema_version": 1,
+  "total_files": 0,
+  "unscanned_files": [],
+  "findings": []
+}`
+	if string(got) != want {
+		t.Errorf("MarshalFindings() =\n%s\nwant\n%s", got, want)
+	}
+}
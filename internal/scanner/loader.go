@@ -0,0 +1,152 @@
+package scanner
+
+import (
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// patternFile is the layout of a custom pattern file
+type patternFile struct {
+	// GenericMinLength applies to generic patterns that don't set their
+	// own min_length
+	GenericMinLength int            `yaml:"generic_min_length"`
+	Patterns         []PatternEntry `yaml:"patterns"`
+	// DisabledPatterns removes patterns by name, including built-in ones
+	DisabledPatterns []string `yaml:"disabled_patterns"`
+}
+
+// PatternEntry is a single pattern definition in a pattern file
+type PatternEntry struct {
+	Name        string `yaml:"name" json:"name"`
+	Regex       string `yaml:"regex" json:"regex"`
+	Description string `yaml:"description,omitempty" json:"description,omitempty"`
+	Severity    string `yaml:"severity" json:"severity"`
+	Generic     bool   `yaml:"generic,omitempty" json:"generic,omitempty"`
+	MinLength   int    `yaml:"min_length,omitempty" json:"min_length,omitempty"`
+	Multiline   bool   `yaml:"multiline,omitempty" json:"multiline,omitempty"`
+	Remediation string `yaml:"remediation,omitempty" json:"remediation,omitempty"`
+}
+
+// LoadPatterns returns the default patterns merged with those defined in
+// the YAML file at path. A file pattern with the same name as a built-in
+// pattern replaces it. An empty path returns the defaults.
+func LoadPatterns(path string) ([]SecretPattern, error) {
+	patterns := GetDefaultPatterns()
+	if path == "" {
+		return patterns, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pattern file: %w", err)
+	}
+
+	var file patternFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse pattern file: %w", err)
+	}
+
+	for _, entry := range file.Patterns {
+		pattern, err := entry.Compile()
+		if err != nil {
+			return nil, err
+		}
+
+		replaced := false
+		for i := range patterns {
+			if patterns[i].Name == pattern.Name {
+				patterns[i] = pattern
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			patterns = append(patterns, pattern)
+		}
+	}
+
+	if file.GenericMinLength < 0 {
+		return nil, fmt.Errorf("generic_min_length must not be negative")
+	}
+	for i := range patterns {
+		if patterns[i].Generic && patterns[i].MinLength == 0 {
+			patterns[i].MinLength = file.GenericMinLength
+		}
+	}
+
+	// Names that no longer match a pattern are harmless, so they're
+	// ignored
+	patterns, _ = DisablePatterns(patterns, file.DisabledPatterns)
+	return patterns, nil
+}
+
+// DisablePatterns returns patterns without the ones named in disabled,
+// along with the disabled names that match no pattern. Names are matched
+// case-insensitively.
+func DisablePatterns(patterns []SecretPattern, disabled []string) ([]SecretPattern, []string) {
+	if len(disabled) == 0 {
+		return patterns, nil
+	}
+
+	matched := make(map[string]bool, len(disabled))
+	var kept []SecretPattern
+	for _, pattern := range patterns {
+		off := false
+		for _, name := range disabled {
+			if strings.EqualFold(name, pattern.Name) {
+				matched[name] = true
+				off = true
+			}
+		}
+		if !off {
+			kept = append(kept, pattern)
+		}
+	}
+
+	var unknown []string
+	for _, name := range disabled {
+		if !matched[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	return kept, unknown
+}
+
+// Compile validates a pattern entry and converts it to a SecretPattern
+func (e PatternEntry) Compile() (SecretPattern, error) {
+	if e.Name == "" {
+		return SecretPattern{}, fmt.Errorf("pattern is missing a name")
+	}
+	if SeverityRank(e.Severity) == 0 {
+		return SecretPattern{}, fmt.Errorf("pattern %q has invalid severity %q", e.Name, e.Severity)
+	}
+
+	if e.MinLength < 0 {
+		return SecretPattern{}, fmt.Errorf("pattern %q has negative min_length", e.Name)
+	}
+
+	re, err := regexp.Compile(e.Regex)
+	if err != nil {
+		return SecretPattern{}, fmt.Errorf("pattern %q has invalid regex: %w", e.Name, err)
+	}
+
+	description := e.Description
+	if description == "" {
+		description = e.Name + " detected"
+	}
+
+	return SecretPattern{
+		Name:        e.Name,
+		Pattern:     re,
+		Description: description,
+		Severity:    e.Severity,
+		Generic:     e.Generic,
+		MinLength:   e.MinLength,
+		Multiline:   e.Multiline,
+		Remediation: e.Remediation,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package scanner
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/diff"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// addedBlock is a run of consecutive added lines joined with newlines
+type addedBlock struct {
+	text   string
+	starts []int // offset in text where each line begins
+	first  int   // index in lines of the block's first line
+}
+
+// addedBlocks splits diff lines into runs of consecutive added lines. A
+// run ends at any other line and at a hunk header.
+func addedBlocks(lines []diff.Line) []addedBlock {
+	var blocks []addedBlock
+	var current *addedBlock
+	var text strings.Builder
+
+	flush := func() {
+		if current != nil {
+			current.text = text.String()
+			blocks = append(blocks, *current)
+			current = nil
+			text.Reset()
+		}
+	}
+
+	for i, line := range lines {
+		if line.Kind != diff.Added {
+			flush()
+			continue
+		}
+		if current != nil && line.Index != lines[i-1].Index+1 {
+			flush()
+		}
+		if current == nil {
+			current = &addedBlock{first: i}
+		} else {
+			text.WriteByte('\n')
+		}
+		current.starts = append(current.starts, text.Len())
+		text.WriteString(line.Text)
+	}
+	flush()
+
+	return blocks
+}
+
+// lineAt returns the index in lines of the line containing offset
+func (b addedBlock) lineAt(offset int) int {
+	return b.first + sort.Search(len(b.starts), func(i int) bool {
+		return b.starts[i] > offset
+	}) - 1
+}
+
+// scanMultiline runs multiline patterns against each block of added lines.
+// Findings are reported at the line where the match starts.
+func scanMultiline(patterns []SecretPattern, lines []diff.Line, filename string, values map[string]string) []models.SecurityIssue {
+	var issues []models.SecurityIssue
+
+	for _, block := range addedBlocks(lines) {
+		for _, pattern := range patterns {
+			for _, loc := range pattern.Pattern.FindAllStringSubmatchIndex(block.text, -1) {
+				line := block.lineAt(loc[0])
+				if ShouldIgnoreLine(lines[line].Text) {
+					continue
+				}
+
+				match := block.text[loc[0]:loc[1]]
+				value := strings.Trim(match, " \t'\"`")
+				if i := pattern.Pattern.SubexpIndex("value"); i > 0 && loc[2*i] >= 0 {
+					value = block.text[loc[2*i]:loc[2*i+1]]
+				}
+				if pattern.MinLength > 0 && len(value) < pattern.MinLength {
+					continue
+				}
+				if pattern.Check != nil && !pattern.Check(value) {
+					continue
+				}
+
+				fingerprint := Fingerprint(pattern.Name, filename, value)
+				if values != nil {
+					values[fingerprint] = value
+				}
+				issues = append(issues, models.SecurityIssue{
+					Type:        pattern.Name,
+					FilePath:    filename,
+					LineNumber:  lines[line].Index,
+					Match:       Redact(value),
+					Severity:    pattern.Severity,
+					Description: pattern.Description,
+					Remediation: pattern.Remediation,
+					Pattern:     pattern.Name,
+					Fingerprint: fingerprint,
+				})
+			}
+		}
+	}
+
+	return issues
+}
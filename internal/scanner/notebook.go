@@ -0,0 +1,104 @@
+package scanner
+
+import (
+	"encoding/json"
+	"path"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/diff"
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// notebookLine is a logical line decoded from a notebook diff
+type notebookLine struct {
+	line int // line in the patch it was decoded from
+	cell int // 1-based cell, 0 when unknown
+}
+
+// IsNotebook reports whether filename is a Jupyter notebook
+func IsNotebook(filename string) bool {
+	return strings.EqualFold(path.Ext(filename), ".ipynb")
+}
+
+// scanNotebook scans the added lines of a Jupyter notebook diff. Notebooks
+// are JSON, so cell sources and outputs are JSON strings with escaped
+// newlines; each string is decoded and scanned line by line, along with
+// any text hidden in base64 outputs. Findings keep the line in the patch
+// and, when the patch shows enough of the file to count cells, the cell.
+func scanNotebook(patterns []SecretPattern, parsed *diff.Patch, filename string, values map[string]string) []models.SecurityIssue {
+	var decoded []diff.Line
+	var origins []notebookLine
+
+	// Cells can only be counted while the diff covers the file from its
+	// first line without gaps
+	cell, counted := 0, true
+	newLine := 1
+	for _, hunk := range parsed.Hunks {
+		if hunk.NewStart != newLine {
+			counted = false
+		}
+		newLine = hunk.NewStart
+
+		for _, line := range hunk.Lines {
+			if line.NewLine == 0 {
+				continue
+			}
+			newLine = line.NewLine + 1
+
+			if strings.Contains(line.Text, `"cell_type":`) {
+				cell++
+			}
+			if line.Kind != diff.Added {
+				continue
+			}
+
+			origin := notebookLine{line: line.Index}
+			if counted {
+				origin.cell = cell
+			}
+			for _, text := range notebookText(line.Text) {
+				decoded = appendDecoded(decoded, text)
+				origins = append(origins, origin)
+
+				for _, text := range decodeBase64Text(text) {
+					decoded = appendDecoded(decoded, text)
+					origins = append(origins, origin)
+				}
+			}
+		}
+	}
+
+	issues := scanLines(patterns, decoded, filename, values)
+	for i := range issues {
+		origin := origins[issues[i].LineNumber-1]
+		issues[i].LineNumber = origin.line
+		issues[i].Cell = origin.cell
+		// Columns point into the decoded text, not the notebook's JSON
+		issues[i].StartColumn, issues[i].EndColumn = 0, 0
+	}
+	return issues
+}
+
+// notebookText returns the logical lines of a line of notebook JSON. A
+// JSON string, alone or as the value of a key, is decoded and split on
+// its newlines; anything else is returned as is.
+func notebookText(content string) []string {
+	trimmed := strings.TrimSuffix(strings.TrimSpace(content), ",")
+
+	var value string
+	if err := json.Unmarshal([]byte(trimmed), &value); err != nil {
+		var field map[string]any
+		if err := json.Unmarshal([]byte("{"+trimmed+"}"), &field); err != nil || len(field) != 1 {
+			return []string{content}
+		}
+		for _, v := range field {
+			s, ok := v.(string)
+			if !ok {
+				return []string{content}
+			}
+			value = s
+		}
+	}
+
+	return strings.Split(strings.TrimSuffix(value, "\n"), "\n")
+}
@@ -0,0 +1,148 @@
+package scanner
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/atomicfile"
+	"gopkg.in/yaml.v3"
+)
+
+// AddPatternToFile validates entry and writes it to the pattern file at
+// path, replacing a pattern of the same name and re-enabling it if it was
+// disabled. The file is created if missing; comments are kept.
+func AddPatternToFile(path string, entry PatternEntry) error {
+	if _, err := entry.Compile(); err != nil {
+		return err
+	}
+
+	return editPatternFile(path, func(root *yaml.Node) error {
+		var node yaml.Node
+		if err := node.Encode(entry); err != nil {
+			return fmt.Errorf("failed to encode pattern: %w", err)
+		}
+
+		patterns := sequenceValue(root, "patterns")
+		replaced := false
+		for i, existing := range patterns.Content {
+			if strings.EqualFold(nodeField(existing, "name"), entry.Name) {
+				patterns.Content[i] = &node
+				replaced = true
+			}
+		}
+		if !replaced {
+			patterns.Content = append(patterns.Content, &node)
+		}
+
+		if disabled := mappingValue(root, "disabled_patterns"); disabled != nil && disabled.Kind == yaml.SequenceNode {
+			kept := disabled.Content[:0]
+			for _, name := range disabled.Content {
+				if !strings.EqualFold(name.Value, entry.Name) {
+					kept = append(kept, name)
+				}
+			}
+			disabled.Content = kept
+		}
+		return nil
+	})
+}
+
+// DisablePatternInFile adds name to the disabled patterns of the pattern
+// file at path, creating the file if missing
+func DisablePatternInFile(path, name string) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("pattern name is required")
+	}
+
+	return editPatternFile(path, func(root *yaml.Node) error {
+		disabled := sequenceValue(root, "disabled_patterns")
+		for _, existing := range disabled.Content {
+			if strings.EqualFold(existing.Value, name) {
+				return nil
+			}
+		}
+		disabled.Content = append(disabled.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: name})
+		return nil
+	})
+}
+
+// editPatternFile applies edit to the top-level mapping of the pattern
+// file at path. The result is checked with LoadPatterns before it
+// replaces the file, so a failed edit leaves the file as it was.
+func editPatternFile(path string, edit func(root *yaml.Node) error) error {
+	data, err := atomicfile.Read(path)
+	if err != nil {
+		return fmt.Errorf("failed to read pattern file: %w", err)
+	}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return fmt.Errorf("failed to parse pattern file: %w", err)
+	}
+	if doc.Kind == 0 {
+		doc.Kind = yaml.DocumentNode
+	}
+	if len(doc.Content) == 0 {
+		doc.Content = []*yaml.Node{{Kind: yaml.MappingNode, Tag: "!!map"}}
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return fmt.Errorf("pattern file is not a mapping")
+	}
+
+	if err := edit(root); err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	encoder := yaml.NewEncoder(&out)
+	encoder.SetIndent(2)
+	if err := encoder.Encode(&doc); err != nil {
+		return fmt.Errorf("failed to encode pattern file: %w", err)
+	}
+	encoder.Close()
+
+	return atomicfile.Write(path, out.Bytes(), 0o644, func(name string) error {
+		_, err := LoadPatterns(name)
+		return err
+	})
+}
+
+// sequenceValue returns the block sequence stored under key in mapping,
+// adding an empty one if key is missing or null
+func sequenceValue(mapping *yaml.Node, key string) *yaml.Node {
+	if value := mappingValue(mapping, key); value != nil {
+		if value.Kind != yaml.SequenceNode {
+			*value = yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+		}
+		value.Style &^= yaml.FlowStyle
+		return value
+	}
+
+	value := &yaml.Node{Kind: yaml.SequenceNode, Tag: "!!seq"}
+	mapping.Content = append(mapping.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: key}, value)
+	return value
+}
+
+// mappingValue returns the node stored under key in a mapping node, or
+// nil if there is none
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	if mapping.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// nodeField returns the scalar stored under key in a mapping node
+func nodeField(mapping *yaml.Node, key string) string {
+	if value := mappingValue(mapping, key); value != nil {
+		return value.Value
+	}
+	return ""
+}
@@ -8,6 +8,40 @@ type SecretPattern struct {
 	Pattern     *regexp.Regexp
 	Description string
 	Severity    string
+	// Block marks patterns that only match the header of a multi-line
+	// secret; the body is verified separately before reporting
+	Block bool
+	// Generic marks keyword-based patterns such as "password = ..." that
+	// match arbitrary values
+	Generic bool
+	// MinLength is the minimum length of the matched value; shorter
+	// matches are not reported. The value is the "value" capture group
+	// when the pattern has one, otherwise the whole match.
+	MinLength int
+	// Multiline patterns run against each block of consecutive added
+	// lines instead of line by line, so they can match across lines
+	Multiline bool
+	// Check, if set, confirms the matched value looks like a real secret;
+	// matches it rejects are not reported
+	Check func(value string) bool
+	// Remediation is a short next step for whoever has to fix a finding
+	Remediation string
+}
+
+// SeverityRank orders severities from LOW (1) to CRITICAL (4). Unknown
+// severities rank 0.
+func SeverityRank(severity string) int {
+	switch severity {
+	case "CRITICAL":
+		return 4
+	case "HIGH":
+		return 3
+	case "MEDIUM":
+		return 2
+	case "LOW":
+		return 1
+	}
+	return 0
 }
 
 // GetDefaultPatterns returns the built-in secret detection patterns
@@ -18,132 +52,211 @@ func GetDefaultPatterns() []SecretPattern {
 			Pattern:     regexp.MustCompile(`(A3T[A-Z0-9]|AKIA|AGPA|AIDA|AROA|AIPA|ANPA|ANVA|ASIA)[A-Z0-9]{16}`),
 			Description: "AWS Access Key ID detected",
 			Severity:    "CRITICAL",
+			Remediation: "Deactivate the key in IAM, create a new one and review CloudTrail for its use",
 		},
 		{
 			Name:        "AWS Secret Access Key",
 			Pattern:     regexp.MustCompile(`(?i)aws(.{0,20})?['\"][0-9a-zA-Z\/+]{40}['\"]`),
 			Description: "AWS Secret Access Key detected",
 			Severity:    "CRITICAL",
+			Remediation: "Deactivate the key pair in IAM, create a new one and review CloudTrail for its use",
+		},
+		{
+			// STS session tokens are base64 and start with one of a few
+			// fixed prefixes; the keyword form catches other encodings
+			Name:        "AWS Session Token",
+			Pattern:     regexp.MustCompile(`(?:FwoGZXIvYXdz|IQoJb3JpZ2luX2Vj)[A-Za-z0-9/+=]{100,}|(?i)aws_?session_?token['\"]?\s*[:=]\s*['\"]?[A-Za-z0-9/+=]{100,}`),
+			Description: "AWS temporary session token detected",
+			Severity:    "CRITICAL",
+			Remediation: "Revoke the role's active sessions in IAM and review CloudTrail for its use",
+		},
+		{
+			Name:        "AWS MWS Auth Token",
+			Pattern:     regexp.MustCompile(`amzn\.mws\.[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12}`),
+			Description: "Amazon Marketplace Web Service auth token detected",
+			Severity:    "HIGH",
+			Remediation: "Revoke the developer's access in Seller Central and authorize again",
 		},
 		{
 			Name:        "GitHub Personal Access Token",
 			Pattern:     regexp.MustCompile(`ghp_[a-zA-Z0-9]{36}`),
 			Description: "GitHub personal access token detected",
 			Severity:    "CRITICAL",
+			Remediation: "Delete the token under Settings > Developer settings and create a new one",
 		},
 		{
 			Name:        "GitHub OAuth Token",
 			Pattern:     regexp.MustCompile(`gho_[a-zA-Z0-9]{36}`),
 			Description: "GitHub OAuth access token detected",
 			Severity:    "CRITICAL",
+			Remediation: "Revoke the OAuth app's authorization and sign in again for a new token",
 		},
 		{
 			Name:        "GitHub App Token",
 			Pattern:     regexp.MustCompile(`(ghu|ghs)_[a-zA-Z0-9]{36}`),
 			Description: "GitHub App token detected",
 			Severity:    "CRITICAL",
+			Remediation: "Revoke the token through the API and let the app request a new one",
 		},
 		{
 			Name:        "GitHub Refresh Token",
 			Pattern:     regexp.MustCompile(`ghr_[a-zA-Z0-9]{36}`),
 			Description: "GitHub refresh token detected",
 			Severity:    "CRITICAL",
+			Remediation: "Revoke the app's authorization so the refresh token stops working",
 		},
 		{
 			Name:        "OpenAI API Key",
 			Pattern:     regexp.MustCompile(`sk-[a-zA-Z0-9]{48}`),
 			Description: "OpenAI API key detected",
 			Severity:    "CRITICAL",
+			Remediation: "Revoke the key on the OpenAI API keys page and create a new one",
+		},
+		{
+			Name:        "Slack Bot Token",
+			Pattern:     regexp.MustCompile(`xoxb-[0-9]{8,13}-(?:[0-9]{8,13}-)?[a-zA-Z0-9]{24}\b`),
+			Description: "Slack bot token detected",
+			Severity:    "CRITICAL",
+			Remediation: "Revoke the token with auth.revoke or by reinstalling the Slack app, and store the new one in a secrets manager",
+		},
+		{
+			Name:        "Slack User Token",
+			Pattern:     regexp.MustCompile(`xoxp-[0-9]{8,13}-[0-9]{8,13}-[0-9]{8,13}-[a-f0-9]{32}\b`),
+			Description: "Slack user token detected",
+			Severity:    "CRITICAL",
+			Remediation: "Revoke the token with auth.revoke or by reinstalling the Slack app; it acts as the user who installed it",
+		},
+		{
+			Name:        "Slack App Token",
+			Pattern:     regexp.MustCompile(`xapp-[0-9]-[A-Z0-9]{9,12}-[0-9]{10,13}-[a-f0-9]{64}\b`),
+			Description: "Slack app-level token detected",
+			Severity:    "CRITICAL",
+			Remediation: "Revoke the app-level token under the Slack app's Basic Information settings and generate a new one",
 		},
 		{
-			Name:        "Slack Token",
-			Pattern:     regexp.MustCompile(`xox[baprs]-[0-9a-zA-Z]{10,48}`),
-			Description: "Slack token detected",
+			Name:        "Slack Configuration Token",
+			Pattern:     regexp.MustCompile(`xoxe(?:\.xox[bp])?-[0-9]-[a-zA-Z0-9]{140,170}\b`),
+			Description: "Slack app configuration token detected",
 			Severity:    "CRITICAL",
+			Remediation: "Revoke the configuration token under Your App Configuration Tokens on api.slack.com/apps",
+		},
+		{
+			Name:        "Slack Legacy Token",
+			Pattern:     regexp.MustCompile(`xox[aors]-(?:[0-9]{1,13}-)+[a-zA-Z0-9]{8,64}\b`),
+			Description: "Legacy Slack token detected",
+			Severity:    "CRITICAL",
+			Remediation: "Revoke the legacy token with auth.revoke and move the integration to a Slack app",
 		},
 		{
 			Name:        "Slack Webhook",
 			Pattern:     regexp.MustCompile(`https://hooks\.slack\.com/services/T[a-zA-Z0-9_]+/B[a-zA-Z0-9_]+/[a-zA-Z0-9_]+`),
 			Description: "Slack webhook URL detected",
 			Severity:    "HIGH",
+			Remediation: "Regenerate the webhook URL in the Slack app's Incoming Webhooks settings",
 		},
 		{
 			Name:        "Generic API Key",
-			Pattern:     regexp.MustCompile(`(?i)(api[_-]?key|apikey)\s*[:=]\s*['\"][a-zA-Z0-9]{20,}['\"]`),
+			Pattern:     regexp.MustCompile(`(?i)(api[_-]?key|apikey)\s*[:=]\s*['\"](?P<value>[a-zA-Z0-9]{20,})['\"]`),
 			Description: "Generic API key pattern detected",
 			Severity:    "HIGH",
+			Remediation: "Revoke the key with its provider, issue a new one and load it from the environment or a secrets manager",
+			Generic:     true,
 		},
 		{
 			Name:        "Generic Secret",
-			Pattern:     regexp.MustCompile(`(?i)(secret|password|passwd|pwd|token)\s*[:=]\s*['\"][^'\"]{8,}['\"]`),
+			Pattern:     regexp.MustCompile(`(?i)(secret|password|passwd|pwd|token)\s*[:=]\s*['\"](?P<value>[^'\"]{8,})['\"]`),
 			Description: "Generic secret pattern detected",
 			Severity:    "MEDIUM",
+			Remediation: "Change the secret and load it from the environment or a secrets manager",
+			Generic:     true,
+		},
+		{
+			// Unquoted KEY=value assignments as in .env files, shell
+			// scripts and Dockerfile ENV lines; $VAR references and paths
+			// are left alone
+			Name:        "Environment Variable Secret",
+			Pattern:     regexp.MustCompile(`^\+?\s*(?:export\s+|ENV\s+)?[A-Z0-9_]*(?:PASSWORD|PASSWD|PWD|SECRET|TOKEN|API_?KEY|ACCESS_?KEY|PRIVATE_?KEY|AUTH_?KEY|CREDENTIALS?)\s*=\s*(?P<value>[^\s'"\x60$#{}<>()]{12,})(?:\s+#.*)?\s*$`),
+			Description: "Hardcoded secret in an environment variable assignment",
+			Severity:    "HIGH",
+			Remediation: "Change the secret, remove it from the file and set it in the deployment environment or a secrets manager",
+			Generic:     true,
+			Check:       checkEnvSecret,
 		},
 		{
 			Name:        "Private Key",
-			Pattern:     regexp.MustCompile(`-----BEGIN (RSA|DSA|EC|OPENSSH|PGP) PRIVATE KEY-----`),
+			Pattern:     regexp.MustCompile(`-----BEGIN ((RSA|DSA|EC|OPENSSH|PGP|ENCRYPTED) )?PRIVATE KEY( BLOCK)?-----`),
 			Description: "Private key detected",
 			Severity:    "CRITICAL",
+			Remediation: "Treat the key as compromised: replace it, revoke any certificates and remove the old public key everywhere it is trusted",
+			Block:       true,
 		},
 		{
 			Name:        "Google API Key",
 			Pattern:     regexp.MustCompile(`AIza[0-9A-Za-z\\-_]{35}`),
 			Description: "Google API key detected",
 			Severity:    "CRITICAL",
+			Remediation: "Regenerate the key in the Google Cloud console credentials page and restrict it",
 		},
 		{
 			Name:        "Google OAuth",
 			Pattern:     regexp.MustCompile(`[0-9]+-[0-9A-Za-z_]{32}\.apps\.googleusercontent\.com`),
 			Description: "Google OAuth client ID detected",
 			Severity:    "HIGH",
+			Remediation: "Reset the client secret in the Google Cloud console if it was committed too",
 		},
 		{
 			Name:        "Stripe API Key",
 			Pattern:     regexp.MustCompile(`(sk|pk)_(test|live)_[0-9a-zA-Z]{24,}`),
 			Description: "Stripe API key detected",
 			Severity:    "CRITICAL",
+			Remediation: "Roll the key in the Stripe dashboard and review recent API requests",
 		},
 		{
 			Name:        "Twilio API Key",
 			Pattern:     regexp.MustCompile(`SK[0-9a-fA-F]{32}`),
 			Description: "Twilio API key detected",
 			Severity:    "CRITICAL",
+			Remediation: "Delete the key in the Twilio console and create a new one",
 		},
 		{
 			Name:        "JWT Token",
 			Pattern:     regexp.MustCompile(`eyJ[A-Za-z0-9-_=]+\.eyJ[A-Za-z0-9-_=]+\.?[A-Za-z0-9-_.+/=]*`),
 			Description: "JWT token detected",
 			Severity:    "MEDIUM",
+			Remediation: "Check whether the token is still valid; if so, revoke it or rotate the signing key",
 		},
 		{
 			Name:        "Database Connection String",
 			Pattern:     regexp.MustCompile(`(?i)(mysql|postgres|mongodb|redis)://[^\s]+:[^\s]+@[^\s]+`),
 			Description: "Database connection string with credentials detected",
 			Severity:    "CRITICAL",
+			Remediation: "Change the database user's password and load the connection string from the environment",
+		},
+		{
+			Name:        "Credentials in URL",
+			Pattern:     regexp.MustCompile(`[a-zA-Z][a-zA-Z0-9+.-]*://[^\s:/@'\"]+:[^\s@/'\"]+@[^\s/'\"]+`),
+			Description: "URL with embedded username and password detected",
+			Severity:    "HIGH",
+			Remediation: "Change the password and pass credentials separately from the URL",
+			Check:       checkURLCredentials,
+		},
+		{
+			Name:        "Basic Auth Header",
+			Pattern:     regexp.MustCompile(`(?i)authorization['\"]?\s*[:=,]\s*['\"]?basic\s+(?P<value>[A-Za-z0-9+/]{8,}={0,2})`),
+			Description: "HTTP Basic authorization header with credentials detected",
+			Severity:    "HIGH",
+			Remediation: "Change the password and load it from the environment or a secrets manager",
+			Check:       checkBasicAuth,
 		},
 	}
 }
 
+// ignoredLine matches lines holding examples, stand-ins and notes rather
+// than real secrets. It's compiled once since every scanned line is
+// checked against it.
+var ignoredLine = regexp.MustCompile(`(?i)example|sample|dummy|test|fake|placeholder|your[_-]?key[_-]?here|replace[_-]?with|TODO|FIXME`)
+
 // ShouldIgnoreLine checks if a line should be ignored (e.g., comments, examples)
 func ShouldIgnoreLine(line string) bool {
-	ignorePatterns := []string{
-		`(?i)example`,
-		`(?i)sample`,
-		`(?i)dummy`,
-		`(?i)test`,
-		`(?i)fake`,
-		`(?i)placeholder`,
-		`(?i)your[_-]?key[_-]?here`,
-		`(?i)replace[_-]?with`,
-		`(?i)TODO`,
-		`(?i)FIXME`,
-	}
-
-	for _, pattern := range ignorePatterns {
-		if matched, _ := regexp.MatchString(pattern, line); matched {
-			return true
-		}
-	}
-
-	return false
-}
\ No newline at end of file
+	return ignoredLine.MatchString(line)
+}
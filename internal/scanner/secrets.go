@@ -2,81 +2,521 @@ package scanner
 
 import (
 	"bufio"
+	"regexp"
+	"sort"
 	"strings"
+	"sync"
 
+	"github.com/Rishav176/GitReviewed/internal/diff"
 	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/verify"
+)
+
+// minKeyBodyLength is the minimum amount of base64 key material a private
+// key block needs before it is reported
+const minKeyBodyLength = 64
+
+var (
+	keyBlockEnd  = regexp.MustCompile(`-----END [A-Z ]*PRIVATE KEY( BLOCK)?-----`)
+	keyBlockBody = regexp.MustCompile(`^[A-Za-z0-9+/=]+$`)
 )
 
 // Scanner handles secret detection
 type Scanner struct {
+	mu       sync.RWMutex
 	patterns []SecretPattern
+	baseline *Baseline
+	verifier *verify.Registry
+	workers  int
+
+	// sensitiveFileSeverity is the severity of committed credentials
+	// files; empty disables the check
+	sensitiveFileSeverity string
+
+	// decodeBase64 also scans the decoded content of base64 runs
+	decodeBase64 bool
+
+	// skipKeyFiles turns off the check for committed key files
+	skipKeyFiles bool
+
+	// skipExtensions are the normalized extensions of files ScanFiles
+	// never scans
+	skipExtensions []string
 }
 
 // NewScanner creates a new scanner with default patterns
 func NewScanner() *Scanner {
 	return &Scanner{
-		patterns: GetDefaultPatterns(),
+		patterns:              GetDefaultPatterns(),
+		sensitiveFileSeverity: DefaultSensitiveFileSeverity,
+		skipExtensions:        DefaultSkipExtensions,
 	}
 }
 
 // NewScannerWithPatterns creates a scanner with custom patterns
 func NewScannerWithPatterns(patterns []SecretPattern) *Scanner {
 	return &Scanner{
-		patterns: patterns,
+		patterns:              patterns,
+		sensitiveFileSeverity: DefaultSensitiveFileSeverity,
+		skipExtensions:        DefaultSkipExtensions,
 	}
 }
 
+// Patterns returns the scanner's current pattern set
+func (s *Scanner) Patterns() []SecretPattern {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.patterns
+}
+
+// SetPatterns atomically replaces the pattern set. Scans already in
+// progress keep using the patterns they started with.
+func (s *Scanner) SetPatterns(patterns []SecretPattern) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.patterns = patterns
+}
+
+// SetBaseline replaces the baseline of known findings that ScanFiles
+// filters out. A nil baseline reports everything.
+func (s *Scanner) SetBaseline(baseline *Baseline) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.baseline = baseline
+}
+
+// SetVerifier enables checking findings against provider APIs. A nil
+// verifier disables verification.
+func (s *Scanner) SetVerifier(verifier *verify.Registry) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.verifier = verifier
+}
+
 // ScanDiff scans a diff for secrets
 func (s *Scanner) ScanDiff(diff string, filename string) []models.SecurityIssue {
-	var issues []models.SecurityIssue
+	s.mu.RLock()
+	patterns, decode := s.patterns, s.decodeBase64
+	s.mu.RUnlock()
+	return scanDiff(patterns, diff, filename, nil, decode)
+}
+
+// ScanDiffWithValues is ScanDiff that also returns each finding's matched
+// value keyed by fingerprint. The values are secrets; redact them before
+// showing them anywhere.
+func (s *Scanner) ScanDiffWithValues(diff string, filename string) ([]models.SecurityIssue, map[string]string) {
+	s.mu.RLock()
+	patterns, decode := s.patterns, s.decodeBase64
+	s.mu.RUnlock()
+
+	values := make(map[string]string)
+	return scanDiff(patterns, diff, filename, values, decode), values
+}
+
+// Redact hides most of a secret, keeping a short prefix so the match can
+// still be recognized
+func Redact(value string) string {
+	if len(value) <= 8 {
+		return strings.Repeat("*", len(value))
+	}
+	return value[:4] + strings.Repeat("*", len(value)-4)
+}
 
-	scanner := bufio.NewScanner(strings.NewReader(diff))
-	lineNumber := 0
+// maxDiffLine is the longest diff line scanned. Notebooks and minified
+// files easily exceed bufio's 64KB default.
+const maxDiffLine = 1024 * 1024
 
+// scanDiff scans a diff for secrets using the given patterns, and with
+// decodeBase64 the decoded content of base64 runs too. If values is
+// non-nil, each finding's matched value is recorded in it by fingerprint.
+// Findings are reported at their line in the new file.
+func scanDiff(patterns []SecretPattern, patch string, filename string, values map[string]string, decodeBase64 bool) []models.SecurityIssue {
+	var lines []string
+	scanner := bufio.NewScanner(strings.NewReader(patch))
+	scanner.Buffer(nil, maxDiffLine)
 	for scanner.Scan() {
-		lineNumber++
-		line := scanner.Text()
+		lines = append(lines, scanner.Text())
+	}
+	parsed := diff.ParseLines(lines)
+
+	// Notebooks always decode base64 outputs
+	var issues []models.SecurityIssue
+	if IsNotebook(filename) {
+		issues = scanNotebook(patterns, parsed, filename, values)
+	} else {
+		patchLines := parsed.Lines()
+		issues = scanLines(patterns, patchLines, filename, values)
+		if decodeBase64 {
+			issues = append(issues, scanBase64(patterns, patchLines, filename, values)...)
+		}
+	}
+
+	// The line scans count lines of the patch
+	for i := range issues {
+		issues[i].LineNumber = parsed.Position(issues[i].LineNumber)
+	}
+	return issues
+}
+
+// scanLines scans the added lines of a diff for secrets. Findings are
+// reported at the line's index in the patch.
+func scanLines(patterns []SecretPattern, lines []diff.Line, filename string, values map[string]string) []models.SecurityIssue {
+	var issues []models.SecurityIssue
+
+	// Multiline patterns take a separate, slower path
+	var single, multi []SecretPattern
+	for _, pattern := range patterns {
+		if pattern.Multiline {
+			multi = append(multi, pattern)
+		} else {
+			single = append(single, pattern)
+		}
+	}
 
-		// Skip lines that are removals (start with -)
-		if strings.HasPrefix(line, "-") {
+	for i, line := range lines {
+		// Only added lines are new; context and removed lines were
+		// already in the repository
+		if line.Kind != diff.Added {
 			continue
 		}
 
 		// Skip lines that should be ignored
-		if ShouldIgnoreLine(line) {
+		if ShouldIgnoreLine(line.Text) {
 			continue
 		}
 
-		// Check against all patterns
-		for _, pattern := range s.patterns {
-			if pattern.Pattern.MatchString(line) {
+		// Check against all single-line patterns
+		for _, pattern := range single {
+			if pattern.Pattern.MatchString(line.Text) {
+				// Block secrets are reported at their header line only
+				// once the body has been verified
+				if pattern.Block && !verifyKeyBlock(lines, i) {
+					continue
+				}
+				value := matchedValue(pattern.Pattern, line.Text)
+				if pattern.MinLength > 0 && len(value) < pattern.MinLength {
+					continue
+				}
+				if pattern.Check != nil && !pattern.Check(value) {
+					continue
+				}
+				fingerprint := Fingerprint(pattern.Name, filename, value)
+				if values != nil {
+					values[fingerprint] = value
+				}
+				start, end := matchColumns(pattern.Pattern, line.Text)
 				issues = append(issues, models.SecurityIssue{
 					Type:        pattern.Name,
 					FilePath:    filename,
-					LineNumber:  lineNumber,
+					LineNumber:  line.Index,
+					StartColumn: start,
+					EndColumn:   end,
+					Match:       Redact(value),
 					Severity:    pattern.Severity,
 					Description: pattern.Description,
+					Remediation: pattern.Remediation,
 					Pattern:     pattern.Name,
+					Fingerprint: fingerprint,
 				})
 			}
 		}
 	}
 
+	if len(multi) > 0 {
+		issues = append(issues, scanMultiline(multi, lines, filename, values)...)
+	}
+
 	return issues
 }
 
-// ScanFiles scans multiple diff files
-func (s *Scanner) ScanFiles(files []models.DiffFile) models.ScanResult {
+// ContentAsDiff turns plain file content into an all-additions diff so it
+// can be scanned with ScanDiff. Line numbers map one-to-one to file lines.
+func ContentAsDiff(content string) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = "+" + line
+	}
+	return strings.Join(lines, "\n")
+}
+
+// matchedValue returns the secret value matched in line: the "value"
+// capture group if the pattern has one, otherwise the whole match trimmed
+// of quotes
+func matchedValue(re *regexp.Regexp, line string) string {
+	match := re.FindStringSubmatch(line)
+	if match == nil {
+		return ""
+	}
+	if i := re.SubexpIndex("value"); i > 0 {
+		return match[i]
+	}
+	return strings.Trim(match[0], " \t'\"`")
+}
+
+// matchColumns returns the 1-based, inclusive columns of the value
+// matchedValue returns
+func matchColumns(re *regexp.Regexp, line string) (int, int) {
+	loc := re.FindStringSubmatchIndex(line)
+	if loc == nil {
+		return 0, 0
+	}
+
+	start, end := loc[0], loc[1]
+	if i := re.SubexpIndex("value"); i > 0 && loc[2*i] >= 0 {
+		start, end = loc[2*i], loc[2*i+1]
+	} else {
+		for start < end && strings.ContainsRune(" \t'\"`", rune(line[start])) {
+			start++
+		}
+		for end > start && strings.ContainsRune(" \t'\"`", rune(line[end-1])) {
+			end--
+		}
+	}
+	return start + 1, end
+}
+
+// verifyKeyBlock checks that the private key header at lines[start] is
+// followed by key material. The block is joined from the non-removed lines
+// that follow the header in the same hunk, or from escaped newlines when
+// the key is embedded in a single string literal.
+func verifyKeyBlock(lines []diff.Line, start int) bool {
+	header := lines[start].Text
+	if strings.Contains(header, `\n`) {
+		return hasKeyMaterial(strings.Split(header, `\n`)[1:])
+	}
+
+	var body []string
+	for i := start + 1; i < len(lines); i++ {
+		line := lines[i]
+		// A gap in the patch indexes is a hunk header
+		if line.Kind == diff.Removed || line.Kind == diff.NoNewline || line.Index != lines[i-1].Index+1 {
+			break
+		}
+		body = append(body, line.Text)
+		if keyBlockEnd.MatchString(line.Text) {
+			break
+		}
+	}
+
+	return hasKeyMaterial(body)
+}
+
+// hasKeyMaterial reports whether the lines of a key block contain enough
+// base64 data to be a real key rather than a stray header
+func hasKeyMaterial(body []string) bool {
+	total := 0
+	for _, line := range body {
+		line = strings.TrimSuffix(strings.Trim(line, " \t\"',;+"), `\n`)
+		switch {
+		case line == "":
+			continue
+		case keyBlockEnd.MatchString(line):
+			return total >= minKeyBodyLength
+		case strings.Contains(line, ":"):
+			// Armor headers such as Proc-Type or Version
+			continue
+		case keyBlockBody.MatchString(line):
+			total += len(line)
+		default:
+			return total >= minKeyBodyLength
+		}
+	}
+	return total >= minKeyBodyLength
+}
+
+// verifyIssues checks each finding's secret with verifier, which may be
+// nil to skip verification. Values are looked up by fingerprint.
+func verifyIssues(verifier *verify.Registry, issues []models.SecurityIssue, values map[string]string) []models.SecurityIssue {
+	if verifier == nil {
+		return issues
+	}
+	for i := range issues {
+		issues[i].Verification = string(verifier.Verify(issues[i].Pattern, values[issues[i].Fingerprint]))
+	}
+	return issues
+}
+
+// SortIssues orders issues by file and then line, keeping the order of
+// issues on the same line
+func SortIssues(issues []models.SecurityIssue) {
+	sort.SliceStable(issues, func(i, j int) bool {
+		if issues[i].FilePath != issues[j].FilePath {
+			return issues[i].FilePath < issues[j].FilePath
+		}
+		return issues[i].LineNumber < issues[j].LineNumber
+	})
+}
+
+// findingLocation identifies one occurrence of a secret
+type findingLocation struct {
+	fingerprint string
+	commit      string
+	line        int
+	cell        int
+}
+
+// dedupe drops findings reported twice for the same secret at the same
+// place, keeping the first. The same secret on several lines is reported
+// on each of them, since each one has to be removed.
+func dedupe(issues []models.SecurityIssue) []models.SecurityIssue {
+	seen := make(map[findingLocation]bool, len(issues))
+	var unique []models.SecurityIssue
+	for _, issue := range issues {
+		at := findingLocation{issue.Fingerprint, issue.CommitSHA, issue.LineNumber, issue.Cell}
+		if seen[at] {
+			continue
+		}
+		seen[at] = true
+		unique = append(unique, issue)
+	}
+	return unique
+}
+
+// ScanCommits scans commit messages for secrets. Findings are attributed to
+// the commit SHA and filtered by the baseline like file findings.
+func (s *Scanner) ScanCommits(commits []models.Commit) []models.SecurityIssue {
 	var allIssues []models.SecurityIssue
 
-	for _, file := range files {
-		issues := s.ScanDiff(file.Patch, file.Filename)
+	s.mu.RLock()
+	patterns, baseline, verifier, decode := s.patterns, s.baseline, s.verifier, s.decodeBase64
+	s.mu.RUnlock()
+
+	values := make(map[string]string)
+	for _, commit := range commits {
+		issues := scanDiff(patterns, ContentAsDiff(commit.Message), "", values, decode)
+		for i := range issues {
+			issues[i].CommitSHA = commit.SHA
+		}
 		allIssues = append(allIssues, issues...)
 	}
 
+	return verifyIssues(verifier, baseline.Filter(dedupe(allIssues)), values)
+}
+
+// SetSensitiveFileSeverity sets the severity reported for committed
+// credentials files such as .env; empty disables the check
+func (s *Scanner) SetSensitiveFileSeverity(severity string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.sensitiveFileSeverity = severity
+}
+
+// SetKeyFileCheck sets whether committed key and certificate files such
+// as .pem and .p12 are reported by name
+func (s *Scanner) SetKeyFileCheck(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skipKeyFiles = !enabled
+}
+
+// SetDecodeBase64 sets whether long base64 runs in added lines are decoded
+// and their content scanned as well
+func (s *Scanner) SetDecodeBase64(enabled bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.decodeBase64 = enabled
+}
+
+// SetSkipExtensions replaces the extensions of files ScanFiles never
+// scans, checked before any pattern or file name check. Empty scans every
+// file.
+func (s *Scanner) SetSkipExtensions(extensions []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.skipExtensions = NormalizeExtensions(extensions)
+}
+
+// SkipsFile reports whether ScanFiles skips filename by its extension
+func (s *Scanner) SkipsFile(filename string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return skippedExtension(filename, s.skipExtensions)
+}
+
+// SetConcurrency sets how many files ScanFiles scans in parallel; values
+// below 1 scan serially
+func (s *Scanner) SetConcurrency(workers int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.workers = workers
+}
+
+// ScanFiles scans multiple diff files, spreading them over the configured
+// number of workers. Issues are ordered by file and then line.
+func (s *Scanner) ScanFiles(files []models.DiffFile) models.ScanResult {
+	var allIssues []models.SecurityIssue
+	s.ScanFilesStream(files, func(issue models.SecurityIssue) {
+		allIssues = append(allIssues, issue)
+	})
+	SortIssues(allIssues)
+
 	return models.ScanResult{
 		Found:      len(allIssues) > 0,
 		Issues:     allIssues,
 		TotalFiles: len(files),
 	}
-}
\ No newline at end of file
+}
+
+// ScanFilesStream scans files like ScanFiles but calls emit with each
+// file's findings as soon as that file is scanned, so callers can act on
+// them early without holding every finding. emit is never called
+// concurrently; files finish in no particular order, but each file's
+// findings come in line order.
+func (s *Scanner) ScanFilesStream(files []models.DiffFile, emit func(models.SecurityIssue)) {
+	s.mu.RLock()
+	patterns, baseline, verifier, workers := s.patterns, s.baseline, s.verifier, s.workers
+	sensitiveSeverity, decode, keyFiles := s.sensitiveFileSeverity, s.decodeBase64, !s.skipKeyFiles
+	skipExtensions := s.skipExtensions
+	s.mu.RUnlock()
+
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > len(files) {
+		workers = len(files)
+	}
+
+	// Findings are verified while emitting, so verification stays serial
+	// and within the verifier's rate limit
+	var emitMu sync.Mutex
+	next := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if skippedExtension(files[i].Filename, skipExtensions) {
+					continue
+				}
+
+				values := make(map[string]string)
+				// A key file is only reported once, as the more severe finding
+				var issues []models.SecurityIssue
+				if keyFiles {
+					issues = scanKeyFile(files[i])
+				}
+				if len(issues) == 0 {
+					issues = scanSensitiveFile(files[i], sensitiveSeverity)
+				}
+				issues = append(issues, scanDiff(patterns, files[i].Patch, files[i].Filename, values, decode)...)
+
+				// Fingerprints include the file path, so each file can be
+				// deduplicated on its own
+				SortIssues(issues)
+				issues = baseline.Filter(dedupe(issues))
+
+				emitMu.Lock()
+				for _, issue := range verifyIssues(verifier, issues, values) {
+					emit(issue)
+				}
+				emitMu.Unlock()
+			}
+		}()
+	}
+	for i := range files {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
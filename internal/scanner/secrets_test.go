@@ -0,0 +1,100 @@
+package scanner
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// syntheticPR returns n changed files of 200 lines each, every tenth
+// file holding a token
+func syntheticPR(n int) []models.DiffFile {
+	files := make([]models.DiffFile, n)
+	for i := range files {
+		var patch strings.Builder
+		patch.WriteString("@@ -0,0 +1,200 @@\n")
+		for line := 0; line < 200; line++ {
+			fmt.Fprintf(&patch, "+\tresult%d := compute(input%d, \"value-%d\")\n", line, line, i)
+		}
+		if i%10 == 0 {
+			fmt.Fprintf(&patch, "+\ttoken := \"%s\"\n", testToken)
+		}
+		files[i] = models.DiffFile{
+			Filename:  fmt.Sprintf("pkg/module%03d/file.go", i),
+			Status:    "added",
+			Additions: 200,
+			Changes:   200,
+			Patch:     patch.String(),
+		}
+	}
+	return files
+}
+
+func TestScanDiffSkipsContextAndRemovedLines(t *testing.T) {
+	patch := "@@ -1,3 +1,3 @@ token := \"" + testToken + "\"\n" +
+		" existing := \"" + testToken + "\"\n" +
+		"-old := \"" + otherTestToken + "\"\n" +
+		"+fixed := true\n"
+
+	if issues := NewScanner().ScanDiff(patch, "main.go"); len(issues) != 0 {
+		t.Errorf("got findings on lines that aren't added: %+v", issues)
+	}
+}
+
+func TestScanDiffReportsNewFileLineAcrossHunks(t *testing.T) {
+	patch := "@@ -1,2 +1,2 @@\n" +
+		" package main\n" +
+		"-var a = 1\n" +
+		"+var a = 2\n" +
+		"@@ -20,2 +20,3 @@ func main() {\n" +
+		" \trun()\n" +
+		"+\ttoken := \"" + testToken + "\"\n" +
+		" }\n"
+
+	issue := findingFor(t, NewScanner().ScanDiff(patch, "main.go"), "GitHub Personal Access Token")
+	if issue.LineNumber != 21 {
+		t.Errorf("finding at line %d, want 21", issue.LineNumber)
+	}
+	// Columns count from the start of the file's line, after the tab
+	// and `token := "`
+	if issue.StartColumn != 12 || issue.EndColumn != 11+len(testToken) {
+		t.Errorf("finding at columns %d-%d, want 12-%d", issue.StartColumn, issue.EndColumn, 11+len(testToken))
+	}
+}
+
+func TestScanFilesOrderIndependentOfConcurrency(t *testing.T) {
+	files := syntheticPR(30)
+
+	serial := NewScanner()
+	serial.SetConcurrency(1)
+	want := serial.ScanFiles(files).Issues
+
+	parallel := NewScanner()
+	parallel.SetConcurrency(8)
+	for i := 0; i < 5; i++ {
+		if got := parallel.ScanFiles(files).Issues; !reflect.DeepEqual(got, want) {
+			t.Fatalf("parallel scan returned %d issues in a different order than the serial scan's %d", len(got), len(want))
+		}
+	}
+	if len(want) != 3 {
+		t.Errorf("got %d issues, want 3", len(want))
+	}
+}
+
+func BenchmarkScanFiles(b *testing.B) {
+	files := syntheticPR(300)
+
+	for _, workers := range []int{1, 4, 8} {
+		b.Run(fmt.Sprintf("workers=%d", workers), func(b *testing.B) {
+			s := NewScanner()
+			s.SetConcurrency(workers)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				s.ScanFiles(files)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package scanner
+
+import (
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/Rishav176/GitReviewed/internal/pathmatch"
+)
+
+// severityLevels lists severities by rank, see SeverityRank
+var severityLevels = []string{"", "LOW", "MEDIUM", "HIGH", "CRITICAL"}
+
+// SeverityRule raises or lowers the severity of findings in matching
+// paths. A Path ending in "/" matches everything under that directory;
+// other paths are globs matched against the full path and the base name.
+type SeverityRule struct {
+	Path   string
+	Adjust int // levels to raise (positive) or lower (negative)
+}
+
+// Matches reports whether filename is covered by the rule
+func (r SeverityRule) Matches(filename string) bool {
+	return pathmatch.Match(r.Path, filename)
+}
+
+// AdjustSeverities applies the first matching rule to each file finding,
+// keeping the severity within LOW..CRITICAL. Adjusted findings keep their
+// original severity in OriginalSeverity.
+func AdjustSeverities(issues []models.SecurityIssue, rules []SeverityRule) []models.SecurityIssue {
+	if len(rules) == 0 {
+		return issues
+	}
+
+	adjusted := make([]models.SecurityIssue, len(issues))
+	copy(adjusted, issues)
+	for i, issue := range adjusted {
+		if issue.FilePath == "" || SeverityRank(issue.Severity) == 0 {
+			continue
+		}
+		for _, rule := range rules {
+			if rule.Matches(issue.FilePath) {
+				adjusted[i] = AdjustSeverity(issue, rule.Adjust)
+				break
+			}
+		}
+	}
+	return adjusted
+}
+
+// AdjustSeverity raises (positive) or lowers (negative) the severity of
+// issue by levels, keeping it within LOW..CRITICAL. The severity before
+// the first adjustment is kept in OriginalSeverity.
+func AdjustSeverity(issue models.SecurityIssue, levels int) models.SecurityIssue {
+	if SeverityRank(issue.Severity) == 0 {
+		return issue
+	}
+
+	rank := SeverityRank(issue.Severity) + levels
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(severityLevels)-1 {
+		rank = len(severityLevels) - 1
+	}
+	if severityLevels[rank] != issue.Severity {
+		if issue.OriginalSeverity == "" {
+			issue.OriginalSeverity = issue.Severity
+		}
+		issue.Severity = severityLevels[rank]
+	}
+	return issue
+}
@@ -2,6 +2,9 @@ package slack
 
 import (
 	"fmt"
+	"net/http"
+	"strings"
+	"sync"
 
 	"github.com/Rishav176/GitReviewed/internal/models"
 	"github.com/slack-go/slack"
@@ -11,45 +14,257 @@ import (
 type Client struct {
 	api            *slack.Client
 	defaultChannel string
+
+	mu   sync.RWMutex // guards opts
+	opts Options
+}
+
+// Notification routes used as keys in Options.Channels
+const (
+	// RouteSecurity is used for security alerts of any severity; a
+	// severity-specific route such as "security_critical" takes precedence
+	RouteSecurity       = "security"
+	RoutePush           = "push"
+	RouteAIReview       = "ai_review"
+	RouteReviewComplete = "review_complete"
+	// RouteOps is used for processing failures; they are only posted when
+	// this route is set and never go to the default channel
+	RouteOps = "ops"
+	// RouteDigest is used for the scheduled findings digest, falling back
+	// to the security route
+	RouteDigest = "digest"
+)
+
+// ProjectRoute returns the route for security alerts about a monorepo
+// sub-project, which takes precedence over the severity routes
+func ProjectRoute(project string) string {
+	return "project_" + project
+}
+
+// RepoRoute returns the route for notifications about repo ("owner/name"),
+// which takes precedence over every route but a sub-project's
+func RepoRoute(repo string) string {
+	return "repo_" + strings.ToLower(repo)
+}
+
+// Options holds optional settings for the Slack client
+type Options struct {
+	// HTTPClient is used for Slack API calls; nil uses http.DefaultClient.
+	// It is only read by NewClient.
+	HTTPClient *http.Client
+
+	AlertStyle AlertStyle
+
+	// Channels maps notification routes to channels. Messages without a
+	// matching route go to the default channel.
+	Channels map[string]string
+
+	// MaxReviewChars caps the AI review text posted to the channel; 0
+	// means no cap. With UploadFullReview the complete review is
+	// attached as a file in the message's thread.
+	MaxReviewChars   int
+	UploadFullReview bool
 }
 
 // NewClient creates a new Slack client
-func NewClient(token, defaultChannel string) *Client {
+func NewClient(token, defaultChannel string, opts Options) *Client {
 	return &Client{
-		api:            slack.New(token),
+		api:            newAPI(token, opts.HTTPClient),
 		defaultChannel: defaultChannel,
+		opts:           opts,
+	}
+}
+
+// newAPI creates the Slack API client, using httpClient when set
+func newAPI(token string, httpClient *http.Client) *slack.Client {
+	if httpClient == nil {
+		return slack.New(token)
+	}
+	return slack.New(token, slack.OptionHTTPClient(httpClient))
+}
+
+// SetOptions replaces the client's alert style and channel routes
+func (c *Client) SetOptions(opts Options) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.opts = opts
+}
+
+// options returns the current client options
+func (c *Client) options() Options {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.opts
+}
+
+// channelFor returns the channel for the first route that is configured,
+// falling back to the default channel
+func (c *Client) channelFor(routes ...string) string {
+	channels := c.options().Channels
+	for _, route := range routes {
+		if channel := channels[route]; channel != "" {
+			return channel
+		}
 	}
+	return c.defaultChannel
+}
+
+// securityRoutes returns the routes for a security alert about repo, most
+// specific first, based on the highest severity among issues
+func securityRoutes(base, repo string, issues []models.SecurityIssue) []string {
+	routes := []string{}
+	if project := issuesProject(issues); project != "" {
+		routes = append(routes, ProjectRoute(project))
+	}
+	routes = append(routes, RepoRoute(repo))
+	for _, severity := range severityOrder {
+		if len(groupBySeverity(issues)[severity]) > 0 {
+			routes = append(routes, base+"_"+strings.ToLower(severity))
+			break
+		}
+	}
+	if base != RouteSecurity {
+		routes = append(routes, base)
+	}
+	return append(routes, RouteSecurity)
 }
 
 // SendSecurityAlert sends a security alert about found secrets
 func (c *Client) SendSecurityAlert(ctx models.ReviewContext) error {
-	blocks := BuildSecurityAlertBlocks(ctx)
+	style := c.options().AlertStyle
+	blocks, overflow := fitBlocks(BuildSecurityAlertBlocks(ctx, style), alertTailBlocks, style.maxBlocks())
 
-	_, _, err := c.api.PostMessage(
-		c.defaultChannel,
+	channel, ts, err := c.api.PostMessage(
+		c.channelFor(securityRoutes(RouteSecurity, ctx.Repository.FullName, ctx.ScanResult.Issues)...),
 		slack.MsgOptionBlocks(blocks...),
-		slack.MsgOptionText("Security Alert: Secrets detected in PR", false),
+		slack.MsgOptionText(style.withMention(ctx.ScanResult.Issues, "Security Alert: Secrets detected in PR"), false),
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to send Slack message: %w", err)
+		return fmt.Errorf("failed to send Slack message: %w", classify(err))
+	}
+
+	if err := c.postOverflow(channel, ts, "Security alert continued", overflow); err != nil {
+		return err
+	}
+
+	if !AlertTruncated(ctx.ScanResult.Issues, style) {
+		return nil
+	}
+
+	return c.postIssueList(channel, ts, ctx.ScanResult.Issues)
+}
+
+// postOverflow posts the blocks that didn't fit in a message as threaded
+// follow-ups with the fallback text
+func (c *Client) postOverflow(channel, ts, text string, overflow [][]slack.Block) error {
+	for _, blocks := range overflow {
+		_, _, err := c.api.PostMessage(
+			channel,
+			slack.MsgOptionBlocks(blocks...),
+			slack.MsgOptionText(text, false),
+			slack.MsgOptionTS(ts),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to send Slack thread reply: %w", classify(err))
+		}
 	}
 
 	return nil
 }
 
+// postIssueList posts the full list of issues as threaded follow-ups
+func (c *Client) postIssueList(channel, ts string, issues []models.SecurityIssue) error {
+	for _, listBlocks := range BuildIssueListBlocks(issues) {
+		_, _, err := c.api.PostMessage(
+			channel,
+			slack.MsgOptionBlocks(listBlocks...),
+			slack.MsgOptionText("Full list of detected secrets", false),
+			slack.MsgOptionTS(ts),
+		)
+		if err != nil {
+			return fmt.Errorf("failed to send Slack thread reply: %w", classify(err))
+		}
+	}
+
+	return nil
+}
+
+// SendPushAlert sends a security alert about secrets pushed to a branch
+func (c *Client) SendPushAlert(push models.PushPayload, result models.ScanResult) error {
+	style := c.options().AlertStyle
+	blocks, overflow := fitBlocks(BuildPushAlertBlocks(push, result, style), alertTailBlocks, style.maxBlocks())
+
+	channel, ts, err := c.api.PostMessage(
+		c.channelFor(securityRoutes(RoutePush, push.Repository.FullName, result.Issues)...),
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionText(style.withMention(result.Issues, "Security Alert: Secrets pushed to "+escapeMrkdwn(push.Branch())), false),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to send Slack message: %w", classify(err))
+	}
+
+	if err := c.postOverflow(channel, ts, "Security alert continued", overflow); err != nil {
+		return err
+	}
+
+	if !AlertTruncated(result.Issues, style) {
+		return nil
+	}
+
+	return c.postIssueList(channel, ts, result.Issues)
+}
+
 // SendAIReview sends AI code review to Slack
-func (c *Client) SendAIReview(ctx models.ReviewContext, aiReview string) error {
-	blocks := BuildAIReviewBlocks(ctx, aiReview)
+func (c *Client) SendAIReview(ctx models.ReviewContext, review models.ReviewResult) error {
+	opts := c.options()
+	channel := c.channelFor(RepoRoute(ctx.Repository.FullName), RouteAIReview)
 
-	_, _, err := c.api.PostMessage(
-		c.defaultChannel,
+	fullReview := review.ReviewText
+	truncated := opts.MaxReviewChars > 0 && len(fullReview) > opts.MaxReviewChars
+	if truncated {
+		note := "_... review truncated_"
+		if opts.UploadFullReview {
+			note = "_... review truncated, the full review is attached in the thread_"
+		}
+		review.ReviewText = truncateReview(fullReview, opts.MaxReviewChars, note)
+	}
+
+	blocks := BuildAIReviewBlocks(ctx, review)
+
+	_, ts, err := c.api.PostMessage(
+		channel,
 		slack.MsgOptionBlocks(blocks...),
 		slack.MsgOptionText("AI Code Review Complete", false),
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to send Slack message: %w", err)
+		return fmt.Errorf("failed to send Slack message: %w", classify(err))
+	}
+
+	if truncated && opts.UploadFullReview {
+		filename := fmt.Sprintf("review-%s-pr%d.md", ctx.Repository.Name, ctx.PullRequest.Number)
+		title := fmt.Sprintf("AI review of %s#%d", ctx.Repository.FullName, ctx.PullRequest.Number)
+		return c.UploadFile(channel, ts, filename, title, fullReview)
+	}
+
+	return nil
+}
+
+// UploadFile uploads content as a file to channel, in the thread of
+// threadTS when it is set
+func (c *Client) UploadFile(channel, threadTS, filename, title, content string) error {
+	_, err := c.api.UploadFileV2(slack.UploadFileV2Parameters{
+		Channel:         channel,
+		ThreadTimestamp: threadTS,
+		Filename:        filename,
+		Title:           title,
+		Content:         content,
+		FileSize:        len(content),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upload Slack file: %w", classify(err))
 	}
 
 	return nil
@@ -60,23 +275,101 @@ func (c *Client) SendReviewComplete(ctx models.ReviewContext) error {
 	blocks := BuildReviewCompleteBlocks(ctx)
 
 	_, _, err := c.api.PostMessage(
-		c.defaultChannel,
+		c.channelFor(RepoRoute(ctx.Repository.FullName), RouteReviewComplete, RouteAIReview),
 		slack.MsgOptionBlocks(blocks...),
 		slack.MsgOptionText("PR Review Complete: No issues found", false),
 	)
 
 	if err != nil {
-		return fmt.Errorf("failed to send Slack message: %w", err)
+		return fmt.Errorf("failed to send Slack message: %w", classify(err))
+	}
+
+	return nil
+}
+
+// SendAIUnavailable tells the channel that the AI review of a PR was
+// skipped, e.g. because the provider's quota is exhausted
+func (c *Client) SendAIUnavailable(ctx models.ReviewContext, reason string) error {
+	blocks := BuildAIUnavailableBlocks(ctx, reason)
+
+	_, _, err := c.api.PostMessage(
+		c.channelFor(RepoRoute(ctx.Repository.FullName), RouteAIReview),
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionText("AI review unavailable: "+reason, false),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to send Slack message: %w", classify(err))
 	}
 
 	return nil
 }
 
+// SendReviewSkipped tells the channel that the AI review of a PR was
+// skipped on purpose, e.g. because the PR is too large, along with the
+// secret scan results
+func (c *Client) SendReviewSkipped(ctx models.ReviewContext, reason string) error {
+	blocks := BuildReviewSkippedBlocks(ctx, reason)
+
+	_, _, err := c.api.PostMessage(
+		c.channelFor(RepoRoute(ctx.Repository.FullName), RouteAIReview),
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionText("AI review skipped: "+reason, false),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to send Slack message: %w", classify(err))
+	}
+
+	return nil
+}
+
+// SendOpsAlert posts a processing failure to the ops channel. It does
+// nothing when no ops channel is configured.
+func (c *Client) SendOpsAlert(subject, detail string) error {
+	channel := c.options().Channels[RouteOps]
+	if channel == "" {
+		return nil
+	}
+
+	blocks := BuildOpsAlertBlocks(subject, detail)
+
+	_, _, err := c.api.PostMessage(
+		channel,
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionText("GitReviewed error: "+subject, false),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to send Slack message: %w", classify(err))
+	}
+
+	return nil
+}
+
+// SendDigest posts the findings digest. PRs that don't fit in one message
+// are posted in its thread.
+func (c *Client) SendDigest(entries []models.DigestEntry) error {
+	blocks, overflow := fitBlocks(BuildDigestBlocks(entries), 0, maxMessageBlocks)
+
+	channel, ts, err := c.api.PostMessage(
+		c.channelFor(RouteDigest, RouteSecurity),
+		slack.MsgOptionBlocks(blocks...),
+		slack.MsgOptionText("Secrets digest", false),
+	)
+
+	if err != nil {
+		return fmt.Errorf("failed to send Slack message: %w", classify(err))
+	}
+
+	return c.postOverflow(channel, ts, "Secrets digest continued", overflow)
+}
+
 // TestConnection tests the Slack connection
 func (c *Client) TestConnection() error {
 	_, err := c.api.AuthTest()
 	if err != nil {
-		return fmt.Errorf("slack authentication failed: %w", err)
+		return fmt.Errorf("slack authentication failed: %w", classify(err))
 	}
 	return nil
-}
\ No newline at end of file
+}
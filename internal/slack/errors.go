@@ -0,0 +1,35 @@
+package slack
+
+import (
+	"errors"
+
+	"github.com/Rishav176/GitReviewed/internal/apierr"
+	"github.com/slack-go/slack"
+)
+
+// authErrors are the Slack API error codes caused by the bot token
+var authErrors = map[string]bool{
+	"invalid_auth":     true,
+	"not_authed":       true,
+	"token_revoked":    true,
+	"token_expired":    true,
+	"account_inactive": true,
+	"missing_scope":    true,
+}
+
+// classify tags a Slack API error with its apierr kind
+func classify(err error) error {
+	var rateErr *slack.RateLimitedError
+	var respErr slack.SlackErrorResponse
+	var statusErr slack.StatusCodeError
+
+	switch {
+	case errors.As(err, &rateErr):
+		return apierr.Wrap(apierr.ErrRateLimited, err)
+	case errors.As(err, &respErr) && authErrors[respErr.Err]:
+		return apierr.Wrap(apierr.ErrAuth, err)
+	case errors.As(err, &statusErr):
+		return apierr.Wrap(apierr.FromStatus(statusErr.Code), err)
+	}
+	return apierr.FromNetwork(err)
+}
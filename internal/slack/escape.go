@@ -0,0 +1,31 @@
+package slack
+
+import "strings"
+
+// mrkdwnEscaper replaces the characters Slack treats as control sequences
+// in mrkdwn text. Escaping "<" and ">" neutralizes mentions such as
+// <!channel> and <!here>, user and channel references, and link syntax.
+var mrkdwnEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+)
+
+// codeEscaper additionally replaces backticks so text cannot close the
+// surrounding code span
+var codeEscaper = strings.NewReplacer(
+	"&", "&amp;",
+	"<", "&lt;",
+	">", "&gt;",
+	"`", "'",
+)
+
+// escapeMrkdwn escapes user-supplied text before it is inserted into mrkdwn
+func escapeMrkdwn(text string) string {
+	return mrkdwnEscaper.Replace(text)
+}
+
+// escapeCode escapes user-supplied text shown inside a `code` span
+func escapeCode(text string) string {
+	return codeEscaper.Replace(text)
+}
@@ -0,0 +1,86 @@
+package slack
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/slack-go/slack"
+)
+
+// maliciousTitle tries to ping everyone and smuggle in a link
+const maliciousTitle = "<!here> urgent fix <https://evil.example/login|Open the PR> & <@U024BE7LH>"
+
+func TestEscapeMrkdwn(t *testing.T) {
+	tests := map[string]string{
+		"Fix login":                       "Fix login",
+		"<!channel>":                      "&lt;!channel&gt;",
+		"<https://evil.example|click>":    "&lt;https://evil.example|click&gt;",
+		"a & b":                           "a &amp; b",
+		"&lt;!here&gt;":                   "&amp;lt;!here&amp;gt;",
+		"*bold* _italic_ ~strike~ `code`": "*bold* _italic_ ~strike~ `code`",
+	}
+	for text, want := range tests {
+		if got := escapeMrkdwn(text); got != want {
+			t.Errorf("escapeMrkdwn(%q) = %q, want %q", text, got, want)
+		}
+	}
+
+	if got, want := escapeCode("a`b<c>"), "a'b&lt;c&gt;"; got != want {
+		t.Errorf("escapeCode() = %q, want %q", got, want)
+	}
+}
+
+// blocksText returns the JSON Slack would receive for blocks, without
+// Go's HTML escaping so control sequences show as they would in Slack
+func blocksText(t *testing.T, blocks []slack.Block) string {
+	t.Helper()
+
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(blocks); err != nil {
+		t.Fatalf("failed to encode blocks: %v", err)
+	}
+	return buf.String()
+}
+
+func TestMaliciousTitleIsNeutralized(t *testing.T) {
+	ctx := models.ReviewContext{
+		Repository: models.Repository{FullName: "octo-org/app"},
+		PullRequest: models.PullRequest{
+			Number:  7,
+			Title:   maliciousTitle,
+			HTMLURL: "https://github.com/octo-org/app/pull/7",
+			User:    models.User{Login: "<!channel>"},
+		},
+		ScanResult: models.ScanResult{Found: true, Issues: []models.SecurityIssue{{
+			Type:       "GitHub Personal Access Token",
+			FilePath:   "deploy.sh",
+			LineNumber: 2,
+			Severity:   "CRITICAL",
+			Match:      "ghp_************************************",
+		}}},
+	}
+
+	messages := map[string][]slack.Block{
+		"security alert":  BuildSecurityAlertBlocks(ctx, AlertStyle{}),
+		"AI review":       BuildAIReviewBlocks(ctx, models.ReviewResult{ReviewText: "Looks fine.", Reviewed: 1}),
+		"AI unavailable":  BuildAIUnavailableBlocks(ctx, "quota exceeded"),
+		"review skipped":  BuildReviewSkippedBlocks(ctx, "only generated files changed"),
+		"review complete": BuildReviewCompleteBlocks(ctx),
+	}
+	for name, blocks := range messages {
+		text := blocksText(t, blocks)
+		for _, raw := range []string{"<!here>", "<!channel>", "<https://evil.example", "<@U024BE7LH>"} {
+			if strings.Contains(text, raw) {
+				t.Errorf("%s message contains %q unescaped", name, raw)
+			}
+		}
+		if !strings.Contains(text, "&lt;!here&gt; urgent fix &lt;https://evil.example/login|Open the PR&gt; &amp; &lt;@U024BE7LH&gt;") {
+			t.Errorf("%s message doesn't show the escaped title: %s", name, text)
+		}
+	}
+}
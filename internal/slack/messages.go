@@ -2,35 +2,63 @@ package slack
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/Rishav176/GitReviewed/internal/models"
 	"github.com/slack-go/slack"
 )
 
+// maxMessageBlocks is Slack's limit on blocks in a single message
+const maxMessageBlocks = 50
+
+// maxSectionText keeps section text under Slack's 3000 character limit
+const maxSectionText = 2900
+
+// alertFixedBlocks counts the security alert blocks outside issue sections,
+// including the optional author history and unscanned files notes
+const alertFixedBlocks = 9
+
+// DefaultMaxIssuesPerSeverity is how many issues of each severity are
+// listed in a security alert before the rest are moved to a thread
+const DefaultMaxIssuesPerSeverity = 5
+
+// severityOrder lists severities from most to least severe
+var severityOrder = []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"}
+
 // BuildSecurityAlertBlocks creates Slack blocks for security alerts
-func BuildSecurityAlertBlocks(ctx models.ReviewContext) []slack.Block {
+func BuildSecurityAlertBlocks(ctx models.ReviewContext, style AlertStyle) []slack.Block {
 	blocks := []slack.Block{}
 
 	// Header
-	headerText := slack.NewTextBlockObject("mrkdwn", 
-		fmt.Sprintf(":rotating_light: *Security Alert: Secrets Detected* :rotating_light:"), 
-		false, false)
+	header := "*Security Alert: Secrets Detected*"
+	if !style.DisableEmoji {
+		header = ":rotating_light: " + header + " :rotating_light:"
+	}
+	header = style.withMention(ctx.ScanResult.Issues, header)
+	headerText := slack.NewTextBlockObject("mrkdwn", header, false, false)
 	headerBlock := slack.NewSectionBlock(headerText, nil, nil)
 	blocks = append(blocks, headerBlock)
 
 	// PR Information
 	prInfoText := slack.NewTextBlockObject("mrkdwn",
 		fmt.Sprintf("*Repository:* %s\n*PR #%d:* <%s|%s>\n*Author:* %s",
-			ctx.Repository.FullName,
+			escapeMrkdwn(ctx.Repository.FullName),
 			ctx.PullRequest.Number,
 			ctx.PullRequest.HTMLURL,
-			ctx.PullRequest.Title,
-			ctx.PullRequest.User.Login,
+			escapeMrkdwn(ctx.PullRequest.Title),
+			escapeMrkdwn(ctx.PullRequest.User.Login),
 		),
 		false, false)
+	if project := issuesProject(ctx.ScanResult.Issues); project != "" {
+		prInfoText.Text += "\n*Project:* " + escapeMrkdwn(project)
+	}
 	prInfoBlock := slack.NewSectionBlock(prInfoText, nil, nil)
 	blocks = append(blocks, prInfoBlock)
 
+	if note := buildAuthorHistoryNote(ctx.AuthorHistory, style); note != nil {
+		blocks = append(blocks, note)
+	}
+
 	// Divider
 	blocks = append(blocks, slack.NewDividerBlock())
 
@@ -44,46 +72,27 @@ func BuildSecurityAlertBlocks(ctx models.ReviewContext) []slack.Block {
 	summaryBlock := slack.NewSectionBlock(summaryText, nil, nil)
 	blocks = append(blocks, summaryBlock)
 
-	// Group issues by severity
-	criticalIssues := []models.SecurityIssue{}
-	highIssues := []models.SecurityIssue{}
-	mediumIssues := []models.SecurityIssue{}
-	lowIssues := []models.SecurityIssue{}
-
-	for _, issue := range ctx.ScanResult.Issues {
-		switch issue.Severity {
-		case "CRITICAL":
-			criticalIssues = append(criticalIssues, issue)
-		case "HIGH":
-			highIssues = append(highIssues, issue)
-		case "MEDIUM":
-			mediumIssues = append(mediumIssues, issue)
-		case "LOW":
-			lowIssues = append(lowIssues, issue)
-		}
+	if note := buildUnscannedNote(ctx.ScanResult); note != nil {
+		blocks = append(blocks, note)
 	}
 
-	// Add issues by severity
-	if len(criticalIssues) > 0 {
-		blocks = append(blocks, buildIssueSection("CRITICAL", "🔴", criticalIssues)...)
-	}
-	if len(highIssues) > 0 {
-		blocks = append(blocks, buildIssueSection("HIGH", "🟠", highIssues)...)
-	}
-	if len(mediumIssues) > 0 {
-		blocks = append(blocks, buildIssueSection("MEDIUM", "🟡", mediumIssues)...)
+	// Add issues by severity, limited so the alert fits in one message
+	groups, commitIssues := alertSections(ctx.ScanResult.Issues)
+	limit := issueLimit(style, groups, commitIssues)
+	for _, severity := range severityOrder {
+		if len(groups[severity]) > 0 {
+			blocks = append(blocks, buildIssueSection(severity, style, groups[severity], limit)...)
+		}
 	}
-	if len(lowIssues) > 0 {
-		blocks = append(blocks, buildIssueSection("LOW", "🟢", lowIssues)...)
+	if len(commitIssues) > 0 {
+		blocks = append(blocks, buildCommitSection(commitIssues, limit)...)
 	}
 
 	// Divider
 	blocks = append(blocks, slack.NewDividerBlock())
 
 	// Action required
-	actionText := slack.NewTextBlockObject("mrkdwn",
-		"*⚠️ Action Required:* Please remove these secrets before merging!",
-		false, false)
+	actionText := slack.NewTextBlockObject("mrkdwn", style.actionText(), false, false)
 	actionBlock := slack.NewSectionBlock(actionText, nil, nil)
 	blocks = append(blocks, actionBlock)
 
@@ -97,8 +106,81 @@ func BuildSecurityAlertBlocks(ctx models.ReviewContext) []slack.Block {
 	return blocks
 }
 
+// BuildPushAlertBlocks creates Slack blocks for secrets found in a direct
+// branch push
+func BuildPushAlertBlocks(push models.PushPayload, result models.ScanResult, style AlertStyle) []slack.Block {
+	blocks := []slack.Block{}
+
+	// Header
+	header := fmt.Sprintf("*Security Alert: Secrets Pushed to %s*", escapeMrkdwn(push.Branch()))
+	if !style.DisableEmoji {
+		header = ":rotating_light: " + header + " :rotating_light:"
+	}
+	header = style.withMention(result.Issues, header)
+	headerText := slack.NewTextBlockObject("mrkdwn", header, false, false)
+	blocks = append(blocks, slack.NewSectionBlock(headerText, nil, nil))
+
+	// Push information
+	pushInfoText := slack.NewTextBlockObject("mrkdwn",
+		fmt.Sprintf("*Repository:* %s\n*Branch:* %s\n*Pushed by:* %s\n*Commits:* <%s|%s...%s>",
+			escapeMrkdwn(push.Repository.FullName),
+			escapeMrkdwn(push.Branch()),
+			escapeMrkdwn(push.Sender.Login),
+			push.Compare,
+			models.ShortSHA(push.Before),
+			models.ShortSHA(push.After),
+		),
+		false, false)
+	if project := issuesProject(result.Issues); project != "" {
+		pushInfoText.Text += "\n*Project:* " + escapeMrkdwn(project)
+	}
+	blocks = append(blocks, slack.NewSectionBlock(pushInfoText, nil, nil))
+
+	// Divider
+	blocks = append(blocks, slack.NewDividerBlock())
+
+	// Issues summary
+	summaryText := slack.NewTextBlockObject("mrkdwn",
+		fmt.Sprintf("*Found %d security issue(s) across %d file(s)*",
+			len(result.Issues),
+			result.TotalFiles,
+		),
+		false, false)
+	blocks = append(blocks, slack.NewSectionBlock(summaryText, nil, nil))
+
+	if note := buildUnscannedNote(result); note != nil {
+		blocks = append(blocks, note)
+	}
+
+	// Add issues by severity
+	groups, commitIssues := alertSections(result.Issues)
+	limit := issueLimit(style, groups, commitIssues)
+	for _, severity := range severityOrder {
+		if len(groups[severity]) > 0 {
+			blocks = append(blocks, buildIssueSection(severity, style, groups[severity], limit)...)
+		}
+	}
+
+	// Divider
+	blocks = append(blocks, slack.NewDividerBlock())
+
+	// These secrets are already on the branch, so they must be rotated
+	actionText := slack.NewTextBlockObject("mrkdwn",
+		style.withEmoji("⚠️", "*Action Required:* These secrets are already on the branch. Rotate them and remove them from the history."),
+		false, false)
+	blocks = append(blocks, slack.NewSectionBlock(actionText, nil, nil))
+
+	// Button to view the changes
+	buttonText := slack.NewTextBlockObject("plain_text", "View Changes", false, false)
+	button := slack.NewButtonBlockElement("view_push", "view_push", buttonText)
+	button.URL = push.Compare
+	blocks = append(blocks, slack.NewActionBlock("push_actions", button))
+
+	return blocks
+}
+
 // BuildAIReviewBlocks creates Slack blocks for AI code review
-func BuildAIReviewBlocks(ctx models.ReviewContext, aiReview string) []slack.Block {
+func BuildAIReviewBlocks(ctx models.ReviewContext, review models.ReviewResult) []slack.Block {
 	blocks := []slack.Block{}
 
 	// Header
@@ -111,11 +193,11 @@ func BuildAIReviewBlocks(ctx models.ReviewContext, aiReview string) []slack.Bloc
 	// PR Information
 	prInfoText := slack.NewTextBlockObject("mrkdwn",
 		fmt.Sprintf("*Repository:* %s\n*PR #%d:* <%s|%s>\n*Author:* %s",
-			ctx.Repository.FullName,
+			escapeMrkdwn(ctx.Repository.FullName),
 			ctx.PullRequest.Number,
 			ctx.PullRequest.HTMLURL,
-			ctx.PullRequest.Title,
-			ctx.PullRequest.User.Login,
+			escapeMrkdwn(ctx.PullRequest.Title),
+			escapeMrkdwn(ctx.PullRequest.User.Login),
 		),
 		false, false)
 	prInfoBlock := slack.NewSectionBlock(prInfoText, nil, nil)
@@ -125,10 +207,21 @@ func BuildAIReviewBlocks(ctx models.ReviewContext, aiReview string) []slack.Bloc
 	blocks = append(blocks, slack.NewDividerBlock())
 
 	// AI Review (split into chunks if too long)
-	reviewText := slack.NewTextBlockObject("mrkdwn", aiReview, false, false)
+	// The review quotes PR content, so it is escaped like any user text
+	reviewText := slack.NewTextBlockObject("mrkdwn", escapeMrkdwn(review.ReviewText), false, false)
 	reviewBlock := slack.NewSectionBlock(reviewText, nil, nil)
 	blocks = append(blocks, reviewBlock)
 
+	if note := buildReviewFailureNote(review); note != nil {
+		blocks = append(blocks, note)
+	}
+	if n := ctx.UnreviewedFiles; n > 0 {
+		capText := slack.NewTextBlockObject("mrkdwn",
+			fmt.Sprintf("⚠️ _The AI review covered the %d highest-priority files; %d more were left out by REVIEW_MAX_FILES. All files were scanned for secrets._", len(ctx.DiffFiles), n),
+			false, false)
+		blocks = append(blocks, slack.NewSectionBlock(capText, nil, nil))
+	}
+
 	// Divider
 	blocks = append(blocks, slack.NewDividerBlock())
 
@@ -142,22 +235,41 @@ func BuildAIReviewBlocks(ctx models.ReviewContext, aiReview string) []slack.Bloc
 	return blocks
 }
 
+// truncateReview shortens review to at most limit bytes including note,
+// cutting at the last file section or line that fits
+func truncateReview(review string, limit int, note string) string {
+	cut := limit - len(note) - 2
+	if cut <= 0 {
+		return note
+	}
+
+	// Drop any rune split by the cut
+	head := strings.ToValidUTF8(review[:cut], "")
+	if i := strings.LastIndex(head, "\n### "); i > 0 {
+		head = head[:i]
+	} else if i := strings.LastIndex(head, "\n"); i > 0 {
+		head = head[:i]
+	}
+
+	return strings.TrimRight(head, "\n") + "\n\n" + note
+}
+
 // buildIssueSection creates a section for a specific severity level
-func buildIssueSection(severity, emoji string, issues []models.SecurityIssue) []slack.Block {
+func buildIssueSection(severity string, style AlertStyle, issues []models.SecurityIssue, limit int) []slack.Block {
 	blocks := []slack.Block{}
 
 	// Severity header
 	headerText := slack.NewTextBlockObject("mrkdwn",
-		fmt.Sprintf("%s *%s Severity* (%d issue(s))", emoji, severity, len(issues)),
+		fmt.Sprintf("%s (%d issue(s))", style.severityHeading(severity), len(issues)),
 		false, false)
 	headerBlock := slack.NewSectionBlock(headerText, nil, nil)
 	blocks = append(blocks, headerBlock)
 
 	// List each issue
 	for i, issue := range issues {
-		if i >= 5 { // Limit to 5 issues per severity to avoid message bloat
+		if i >= limit { // Remaining issues are listed in a thread reply
 			remainingText := slack.NewTextBlockObject("mrkdwn",
-				fmt.Sprintf("_... and %d more %s severity issue(s)_", len(issues)-5, severity),
+				fmt.Sprintf("_... and %d more %s severity issue(s), full list in thread_", len(issues)-limit, severity),
 				false, false)
 			remainingBlock := slack.NewSectionBlock(remainingText, nil, nil)
 			blocks = append(blocks, remainingBlock)
@@ -165,11 +277,14 @@ func buildIssueSection(severity, emoji string, issues []models.SecurityIssue) []
 		}
 
 		issueText := slack.NewTextBlockObject("mrkdwn",
-			fmt.Sprintf("• *%s*\n  `%s` (Line %d)\n  _%s_",
-				issue.Type,
-				issue.FilePath,
-				issue.LineNumber,
-				issue.Description,
+			fmt.Sprintf("• *%s*%s%s\n  `%s` (Line %s)\n  _%s_%s",
+				escapeMrkdwn(issue.Type),
+				issue.VerificationNote(),
+				severityNote(issue),
+				escapeCode(issue.FilePath),
+				issue.Line(),
+				escapeMrkdwn(issue.Description),
+				remediationNote(issue),
 			),
 			false, false)
 		issueBlock := slack.NewSectionBlock(issueText, nil, nil)
@@ -179,6 +294,350 @@ func buildIssueSection(severity, emoji string, issues []models.SecurityIssue) []
 	return blocks
 }
 
+// severityNote shows the original severity of a finding whose severity was
+// adjusted by a path rule
+func severityNote(issue models.SecurityIssue) string {
+	if issue.OriginalSeverity == "" {
+		return ""
+	}
+	return fmt.Sprintf(" _(was %s)_", issue.OriginalSeverity)
+}
+
+// buildCommitSection creates the section for secrets found in commit
+// messages
+func buildCommitSection(issues []models.SecurityIssue, limit int) []slack.Block {
+	blocks := []slack.Block{}
+
+	headerText := slack.NewTextBlockObject("mrkdwn",
+		fmt.Sprintf("*Commit messages* (%d issue(s))", len(issues)),
+		false, false)
+	blocks = append(blocks, slack.NewSectionBlock(headerText, nil, nil))
+
+	for i, issue := range issues {
+		if i >= limit { // Remaining issues are listed in a thread reply
+			remainingText := slack.NewTextBlockObject("mrkdwn",
+				fmt.Sprintf("_... and %d more commit message issue(s), full list in thread_", len(issues)-limit),
+				false, false)
+			blocks = append(blocks, slack.NewSectionBlock(remainingText, nil, nil))
+			break
+		}
+
+		issueText := slack.NewTextBlockObject("mrkdwn",
+			fmt.Sprintf("• *%s* [%s]%s\n  Commit `%s` (Line %d)\n  _%s_%s",
+				escapeMrkdwn(issue.Type),
+				escapeMrkdwn(issue.Severity),
+				issue.VerificationNote(),
+				models.ShortSHA(issue.CommitSHA),
+				issue.LineNumber,
+				escapeMrkdwn(issue.Description),
+				remediationNote(issue),
+			),
+			false, false)
+		blocks = append(blocks, slack.NewSectionBlock(issueText, nil, nil))
+	}
+
+	return blocks
+}
+
+// maxRemediationChars caps the remediation advice shown per issue, which
+// custom patterns could otherwise make arbitrarily long
+const maxRemediationChars = 200
+
+// remediationNote returns the issue's next step on its own line, or ""
+// when its pattern has none
+func remediationNote(issue models.SecurityIssue) string {
+	advice := issue.Remediation
+	if advice == "" {
+		return ""
+	}
+	if len(advice) > maxRemediationChars {
+		advice = strings.ToValidUTF8(advice[:maxRemediationChars], "") + "..."
+	}
+	return "\n  *Next step:* " + escapeMrkdwn(advice)
+}
+
+// BuildIssueListBlocks lists every issue compactly for a thread reply. The
+// list is split into as many messages as needed to respect Slack's limits.
+func BuildIssueListBlocks(issues []models.SecurityIssue) [][]slack.Block {
+	var messages [][]slack.Block
+	blocks := []slack.Block{}
+	var text string
+
+	flush := func() {
+		if text == "" {
+			return
+		}
+		blocks = append(blocks, slack.NewSectionBlock(
+			slack.NewTextBlockObject("mrkdwn", text, false, false), nil, nil))
+		text = ""
+		if len(blocks) == maxMessageBlocks {
+			messages = append(messages, blocks)
+			blocks = []slack.Block{}
+		}
+	}
+
+	groups := groupBySeverity(issues)
+	for _, severity := range severityOrder {
+		for _, issue := range groups[severity] {
+			location := escapeCode(issue.FilePath)
+			if issue.CommitSHA != "" {
+				location = "commit " + models.ShortSHA(issue.CommitSHA)
+			}
+			line := fmt.Sprintf("• [%s] *%s*%s `%s` (Line %s)\n", escapeMrkdwn(issue.Severity), escapeMrkdwn(issue.Type), issue.VerificationNote(), location, issue.Line())
+			if len(text)+len(line) > maxSectionText {
+				flush()
+			}
+			text += line
+		}
+	}
+	flush()
+
+	if len(blocks) > 0 {
+		messages = append(messages, blocks)
+	}
+	return messages
+}
+
+// AlertTruncated reports whether a security alert hides some issues
+func AlertTruncated(issues []models.SecurityIssue, style AlertStyle) bool {
+	groups, commitIssues := alertSections(issues)
+	limit := issueLimit(style, groups, commitIssues)
+	for _, group := range groups {
+		if len(group) > limit {
+			return true
+		}
+	}
+	return len(commitIssues) > limit
+}
+
+// buildUnscannedNote warns about files that could not be scanned, or
+// returns nil when every file was scanned
+func buildUnscannedNote(result models.ScanResult) slack.Block {
+	if len(result.UnscannedFiles) == 0 {
+		return nil
+	}
+
+	noteText := slack.NewTextBlockObject("mrkdwn",
+		fmt.Sprintf("⚠️ _%d file(s) were too large or binary and could not be scanned_", len(result.UnscannedFiles)),
+		false, false)
+	return slack.NewSectionBlock(noteText, nil, nil)
+}
+
+// buildAuthorHistoryNote says whether the author has leaked secrets before,
+// or returns nil when history isn't tracked
+func buildAuthorHistoryNote(history *models.AuthorHistory, style AlertStyle) slack.Block {
+	if history == nil {
+		return nil
+	}
+
+	text := "_First offense: no earlier findings for this author_"
+	if history.PriorPRs > 0 {
+		text = style.withEmoji(":repeat:", fmt.Sprintf("*Repeat offender:* %d earlier PR(s) with %d finding(s) by this author",
+			history.PriorPRs, history.PriorFindings))
+	}
+
+	noteText := slack.NewTextBlockObject("mrkdwn", text, false, false)
+	return slack.NewSectionBlock(noteText, nil, nil)
+}
+
+// maxFailedFilesListed caps how many failed files are named in the
+// review failure note
+const maxFailedFilesListed = 5
+
+// buildReviewFailureNote warns about files the AI could not review, or
+// returns nil when every file was reviewed
+func buildReviewFailureNote(review models.ReviewResult) slack.Block {
+	if len(review.Failed) == 0 {
+		return nil
+	}
+
+	names := review.Failed
+	more := ""
+	if len(names) > maxFailedFilesListed {
+		more = fmt.Sprintf(" and %d more", len(names)-maxFailedFilesListed)
+		names = names[:maxFailedFilesListed]
+	}
+	quoted := make([]string, len(names))
+	for i, name := range names {
+		quoted[i] = fmt.Sprintf("`%s`", escapeCode(name))
+	}
+
+	noteText := slack.NewTextBlockObject("mrkdwn",
+		fmt.Sprintf("⚠️ _%d of %d file(s) could not be reviewed:_ %s%s",
+			len(review.Failed), review.Reviewed+len(review.Failed), strings.Join(quoted, ", "), more),
+		false, false)
+	return slack.NewSectionBlock(noteText, nil, nil)
+}
+
+// issuesProject returns the sub-project shared by all issues, or an empty
+// string when they span several or none
+func issuesProject(issues []models.SecurityIssue) string {
+	if len(issues) == 0 {
+		return ""
+	}
+	for _, issue := range issues[1:] {
+		if issue.Project != issues[0].Project {
+			return ""
+		}
+	}
+	return issues[0].Project
+}
+
+// groupBySeverity groups issues by their severity
+func groupBySeverity(issues []models.SecurityIssue) map[string][]models.SecurityIssue {
+	groups := make(map[string][]models.SecurityIssue)
+	for _, issue := range issues {
+		groups[issue.Severity] = append(groups[issue.Severity], issue)
+	}
+	return groups
+}
+
+// alertSections groups file findings by severity and returns commit message
+// findings separately, as they get their own alert section
+func alertSections(issues []models.SecurityIssue) (map[string][]models.SecurityIssue, []models.SecurityIssue) {
+	var fileIssues, commitIssues []models.SecurityIssue
+	for _, issue := range issues {
+		if issue.CommitSHA != "" {
+			commitIssues = append(commitIssues, issue)
+		} else {
+			fileIssues = append(fileIssues, issue)
+		}
+	}
+	return groupBySeverity(fileIssues), commitIssues
+}
+
+// issueLimit returns how many issues to list per section. The configured
+// limit is lowered when needed so the alert stays within the block budget.
+// At least one issue is always listed; an alert that still doesn't fit
+// overflows into the thread.
+func issueLimit(style AlertStyle, groups map[string][]models.SecurityIssue, commitIssues []models.SecurityIssue) int {
+	limit := style.MaxIssuesPerSeverity
+	if limit <= 0 {
+		limit = DefaultMaxIssuesPerSeverity
+	}
+
+	sections := 0
+	for _, severity := range severityOrder {
+		if len(groups[severity]) > 0 {
+			sections++
+		}
+	}
+	if len(commitIssues) > 0 {
+		sections++
+	}
+	if sections == 0 {
+		return limit
+	}
+
+	// Each section also needs a heading and a "... and N more" block
+	budget := (style.maxBlocks()-alertFixedBlocks)/sections - 2
+	if budget < limit {
+		limit = max(budget, 1)
+	}
+	return limit
+}
+
+// BuildAIUnavailableBlocks creates Slack blocks noting that a PR's AI
+// review was skipped
+func BuildAIUnavailableBlocks(ctx models.ReviewContext, reason string) []slack.Block {
+	text := slack.NewTextBlockObject("mrkdwn",
+		fmt.Sprintf(":warning: *AI review unavailable: %s*\n*Repository:* %s\n*PR #%d:* <%s|%s>\nThe secret scan completed normally.",
+			escapeMrkdwn(reason),
+			escapeMrkdwn(ctx.Repository.FullName),
+			ctx.PullRequest.Number,
+			ctx.PullRequest.HTMLURL,
+			escapeMrkdwn(ctx.PullRequest.Title),
+		),
+		false, false)
+
+	return []slack.Block{slack.NewSectionBlock(text, nil, nil)}
+}
+
+// BuildReviewSkippedBlocks creates Slack blocks noting that a PR's AI
+// review was skipped, with a summary of the secret scan
+func BuildReviewSkippedBlocks(ctx models.ReviewContext, reason string) []slack.Block {
+	blocks := []slack.Block{}
+
+	headerText := slack.NewTextBlockObject("mrkdwn",
+		fmt.Sprintf(":fast_forward: *AI review skipped: %s*\n*Repository:* %s\n*PR #%d:* <%s|%s>\n*Author:* %s",
+			escapeMrkdwn(reason),
+			escapeMrkdwn(ctx.Repository.FullName),
+			ctx.PullRequest.Number,
+			ctx.PullRequest.HTMLURL,
+			escapeMrkdwn(ctx.PullRequest.Title),
+			escapeMrkdwn(ctx.PullRequest.User.Login),
+		),
+		false, false)
+	blocks = append(blocks, slack.NewSectionBlock(headerText, nil, nil))
+
+	scan := fmt.Sprintf("*Secret scan:* no security issues found in %d file(s)", ctx.ScanResult.TotalFiles)
+	if n := len(ctx.ScanResult.Issues); n > 0 {
+		counts := groupBySeverity(ctx.ScanResult.Issues)
+		var parts []string
+		for _, severity := range severityOrder {
+			if c := len(counts[severity]); c > 0 {
+				parts = append(parts, fmt.Sprintf("%d %s", c, strings.ToLower(severity)))
+			}
+		}
+		scan = fmt.Sprintf("*Secret scan:* %d issue(s) in %d file(s) (%s), see the security alert for details",
+			n, ctx.ScanResult.TotalFiles, strings.Join(parts, ", "))
+	}
+	scanText := slack.NewTextBlockObject("mrkdwn", scan, false, false)
+	blocks = append(blocks, slack.NewSectionBlock(scanText, nil, nil))
+
+	if note := buildUnscannedNote(ctx.ScanResult); note != nil {
+		blocks = append(blocks, note)
+	}
+
+	return blocks
+}
+
+// BuildOpsAlertBlocks creates Slack blocks for a processing failure
+func BuildOpsAlertBlocks(subject, detail string) []slack.Block {
+	text := slack.NewTextBlockObject("mrkdwn",
+		fmt.Sprintf(":rotating_light: *%s*\n```%s```", escapeMrkdwn(subject), escapeCode(detail)),
+		false, false)
+	return []slack.Block{slack.NewSectionBlock(text, nil, nil)}
+}
+
+// BuildDigestBlocks creates Slack blocks summarizing the open PRs with
+// outstanding findings, one section per PR with its findings by severity
+func BuildDigestBlocks(entries []models.DigestEntry) []slack.Block {
+	findings := 0
+	for _, entry := range entries {
+		findings += len(entry.Issues)
+	}
+
+	headerText := slack.NewTextBlockObject("mrkdwn",
+		fmt.Sprintf(":clipboard: *Secrets digest: %d open PR(s) with %d outstanding finding(s)*", len(entries), findings),
+		false, false)
+	blocks := []slack.Block{slack.NewSectionBlock(headerText, nil, nil), slack.NewDividerBlock()}
+
+	for _, entry := range entries {
+		groups := groupBySeverity(entry.Issues)
+		var counts []string
+		for _, severity := range severityOrder {
+			if n := len(groups[severity]); n > 0 {
+				counts = append(counts, fmt.Sprintf("%d %s", n, severity))
+			}
+		}
+
+		text := slack.NewTextBlockObject("mrkdwn",
+			fmt.Sprintf("*%s* <%s|#%d %s> by %s\n%s",
+				escapeMrkdwn(entry.Repository.FullName),
+				entry.PullRequest.HTMLURL,
+				entry.PullRequest.Number,
+				escapeMrkdwn(entry.PullRequest.Title),
+				escapeMrkdwn(entry.PullRequest.User.Login),
+				strings.Join(counts, ", "),
+			),
+			false, false)
+		blocks = append(blocks, slack.NewSectionBlock(text, nil, nil))
+	}
+
+	return blocks
+}
+
 // BuildReviewCompleteBlocks creates Slack blocks for successful review
 func BuildReviewCompleteBlocks(ctx models.ReviewContext) []slack.Block {
 	blocks := []slack.Block{}
@@ -193,11 +652,11 @@ func BuildReviewCompleteBlocks(ctx models.ReviewContext) []slack.Block {
 	// PR Information
 	prInfoText := slack.NewTextBlockObject("mrkdwn",
 		fmt.Sprintf("*Repository:* %s\n*PR #%d:* <%s|%s>\n*Author:* %s",
-			ctx.Repository.FullName,
+			escapeMrkdwn(ctx.Repository.FullName),
 			ctx.PullRequest.Number,
 			ctx.PullRequest.HTMLURL,
-			ctx.PullRequest.Title,
-			ctx.PullRequest.User.Login,
+			escapeMrkdwn(ctx.PullRequest.Title),
+			escapeMrkdwn(ctx.PullRequest.User.Login),
 		),
 		false, false)
 	prInfoBlock := slack.NewSectionBlock(prInfoText, nil, nil)
@@ -215,6 +674,10 @@ func BuildReviewCompleteBlocks(ctx models.ReviewContext) []slack.Block {
 	successBlock := slack.NewSectionBlock(successText, nil, nil)
 	blocks = append(blocks, successBlock)
 
+	if note := buildUnscannedNote(ctx.ScanResult); note != nil {
+		blocks = append(blocks, note)
+	}
+
 	// Button to view PR
 	buttonText := slack.NewTextBlockObject("plain_text", "View Pull Request", false, false)
 	button := slack.NewButtonBlockElement("view_pr", "view_pr", buttonText)
@@ -223,4 +686,4 @@ func BuildReviewCompleteBlocks(ctx models.ReviewContext) []slack.Block {
 	blocks = append(blocks, actionBlock)
 
 	return blocks
-}
\ No newline at end of file
+}
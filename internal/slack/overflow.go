@@ -0,0 +1,40 @@
+package slack
+
+import "github.com/slack-go/slack"
+
+// alertTailBlocks counts the blocks that close a security alert: the
+// divider, the call to action and the button
+const alertTailBlocks = 3
+
+// overflowNote marks an alert whose remaining blocks continue in the thread
+const overflowNote = "_... continued in thread_"
+
+// fitBlocks splits blocks so the first message holds at most max blocks.
+// The last tail blocks always stay in the first message, after a note
+// that the rest continues in the thread; the blocks that don't fit are
+// returned as thread replies of at most max blocks each.
+func fitBlocks(blocks []slack.Block, tail, max int) ([]slack.Block, [][]slack.Block) {
+	if len(blocks) <= max || tail >= len(blocks) {
+		return blocks, nil
+	}
+
+	keep := max - tail - 1
+	if keep < 1 {
+		keep = 1
+	}
+	body, closing := blocks[:len(blocks)-tail], blocks[len(blocks)-tail:]
+
+	primary := append([]slack.Block{}, body[:keep]...)
+	primary = append(primary, slack.NewContextBlock("",
+		slack.NewTextBlockObject("mrkdwn", overflowNote, false, false)))
+	primary = append(primary, closing...)
+
+	var overflow [][]slack.Block
+	rest := body[keep:]
+	for len(rest) > 0 {
+		n := min(len(rest), max)
+		overflow = append(overflow, rest[:n])
+		rest = rest[n:]
+	}
+	return primary, overflow
+}
@@ -0,0 +1,111 @@
+package slack
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+	"github.com/slack-go/slack"
+)
+
+// numberedBlocks returns n section blocks whose text is their index
+func numberedBlocks(n int) []slack.Block {
+	blocks := make([]slack.Block, n)
+	for i := range blocks {
+		blocks[i] = slack.NewSectionBlock(slack.NewTextBlockObject("mrkdwn", fmt.Sprint(i), false, false), nil, nil)
+	}
+	return blocks
+}
+
+func TestFitBlocksOverflow(t *testing.T) {
+	blocks := numberedBlocks(120)
+
+	primary, overflow := fitBlocks(blocks, 2, maxMessageBlocks)
+
+	if len(primary) != maxMessageBlocks {
+		t.Fatalf("first message has %d blocks, want %d", len(primary), maxMessageBlocks)
+	}
+	// The first message keeps the start of the body, the note and the tail
+	if primary[46] != blocks[46] {
+		t.Error("first message doesn't start with the body")
+	}
+	if note, ok := primary[47].(*slack.ContextBlock); !ok || note.ContextElements.Elements[0].(*slack.TextBlockObject).Text != overflowNote {
+		t.Errorf("block 48 is %#v, want the overflow note", primary[47])
+	}
+	if primary[48] != blocks[118] || primary[49] != blocks[119] {
+		t.Error("first message doesn't end with the tail blocks")
+	}
+
+	// The rest of the body follows in order, in full replies
+	if len(overflow) != 2 || len(overflow[0]) != maxMessageBlocks || len(overflow[1]) != 21 {
+		t.Fatalf("got %d replies, want replies of 50 and 21 blocks", len(overflow))
+	}
+	next := 47
+	for _, reply := range overflow {
+		for _, block := range reply {
+			if block != blocks[next] {
+				t.Fatalf("reply block is not body block %d", next)
+			}
+			next++
+		}
+	}
+	if next != 118 {
+		t.Errorf("replies end at block %d, want 118", next)
+	}
+}
+
+func TestFitBlocksWithinLimit(t *testing.T) {
+	blocks := numberedBlocks(maxMessageBlocks)
+
+	primary, overflow := fitBlocks(blocks, alertTailBlocks, maxMessageBlocks)
+	if len(primary) != len(blocks) || overflow != nil {
+		t.Errorf("got %d blocks and %d replies, want the blocks unchanged", len(primary), len(overflow))
+	}
+}
+
+func TestSecurityAlertOverflowsIntoThread(t *testing.T) {
+	var issues []models.SecurityIssue
+	for _, severity := range []string{"CRITICAL", "HIGH", "MEDIUM", "LOW"} {
+		for i := 0; i < 5; i++ {
+			issues = append(issues, models.SecurityIssue{
+				Type:       "Generic Secret",
+				FilePath:   fmt.Sprintf("config/%s-%d.yaml", severity, i),
+				LineNumber: i + 1,
+				Severity:   severity,
+				Match:      "pass********",
+			})
+		}
+	}
+	ctx := models.ReviewContext{
+		Repository:  models.Repository{FullName: "octo-org/app"},
+		PullRequest: models.PullRequest{Number: 7, Title: "Add configs"},
+		ScanResult:  models.ScanResult{Found: true, Issues: issues},
+	}
+	style := AlertStyle{MaxBlocks: 12}
+
+	blocks := BuildSecurityAlertBlocks(ctx, style)
+	if len(blocks) <= style.MaxBlocks {
+		t.Fatalf("alert has %d blocks, want more than %d to force an overflow", len(blocks), style.MaxBlocks)
+	}
+
+	primary, overflow := fitBlocks(blocks, alertTailBlocks, style.maxBlocks())
+	if len(primary) > style.MaxBlocks {
+		t.Errorf("first message has %d blocks, want at most %d", len(primary), style.MaxBlocks)
+	}
+	if len(overflow) == 0 {
+		t.Fatal("no blocks were moved to the thread")
+	}
+	total := len(primary) - 1 // the overflow note isn't an alert block
+	for _, reply := range overflow {
+		if len(reply) > style.MaxBlocks {
+			t.Errorf("reply has %d blocks, want at most %d", len(reply), style.MaxBlocks)
+		}
+		total += len(reply)
+	}
+	if total != len(blocks) {
+		t.Errorf("messages hold %d alert blocks, want all %d", total, len(blocks))
+	}
+	if last := primary[len(primary)-1]; last != blocks[len(blocks)-1] {
+		t.Error("first message doesn't end with the alert's closing blocks")
+	}
+}
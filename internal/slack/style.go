@@ -0,0 +1,113 @@
+package slack
+
+import (
+	"strings"
+
+	"github.com/Rishav176/GitReviewed/internal/models"
+)
+
+// SeverityLabel controls how a severity heading is shown in alerts
+type SeverityLabel struct {
+	Emoji string
+	Label string
+}
+
+// AlertStyle customizes the wording and emoji of security alerts. Zero
+// values fall back to the defaults.
+type AlertStyle struct {
+	Severities   map[string]SeverityLabel
+	ActionText   string
+	DisableEmoji bool
+
+	// MaxIssuesPerSeverity caps how many issues of each severity are listed
+	// in the alert itself; the full list is posted in a thread
+	MaxIssuesPerSeverity int
+
+	// MaxBlocks caps the blocks in a single alert message; anything beyond
+	// it is posted in the thread. Values above Slack's limit are ignored.
+	MaxBlocks int
+
+	// Mentions maps severities to Slack mention markup, such as
+	// "<!subteam^S0123ABCD>". An alert mentions the entry of its most
+	// severe finding's severity that has one; without entries no one is
+	// mentioned.
+	Mentions map[string]string
+}
+
+// defaultSeverityLabels are used for severities without a custom label
+var defaultSeverityLabels = map[string]SeverityLabel{
+	"CRITICAL": {Emoji: "🔴", Label: "CRITICAL Severity"},
+	"HIGH":     {Emoji: "🟠", Label: "HIGH Severity"},
+	"MEDIUM":   {Emoji: "🟡", Label: "MEDIUM Severity"},
+	"LOW":      {Emoji: "🟢", Label: "LOW Severity"},
+}
+
+// defaultActionText is the call to action at the end of security alerts
+const defaultActionText = "*Action Required:* Please remove these secrets before merging!"
+
+// severityHeading returns the emoji-prefixed heading for a severity
+func (s AlertStyle) severityHeading(severity string) string {
+	label := defaultSeverityLabels[severity]
+	if custom, ok := s.Severities[severity]; ok {
+		if custom.Emoji != "" {
+			label.Emoji = custom.Emoji
+		}
+		if custom.Label != "" {
+			label.Label = custom.Label
+		}
+	}
+	if label.Label == "" {
+		label.Label = severity
+	}
+
+	return s.withEmoji(label.Emoji, "*"+label.Label+"*")
+}
+
+// actionText returns the call to action shown at the end of alerts
+func (s AlertStyle) actionText() string {
+	if s.ActionText != "" {
+		return s.ActionText
+	}
+	if s.DisableEmoji {
+		return defaultActionText
+	}
+	return strings.Replace(defaultActionText, "*", "*⚠️ ", 1)
+}
+
+// mention returns the mention for an alert about issues, or ""
+func (s AlertStyle) mention(issues []models.SecurityIssue) string {
+	if len(s.Mentions) == 0 {
+		return ""
+	}
+	groups := groupBySeverity(issues)
+	for _, severity := range severityOrder {
+		if mention := s.Mentions[severity]; mention != "" && len(groups[severity]) > 0 {
+			return mention
+		}
+	}
+	return ""
+}
+
+// withMention prefixes text with the alert's mention, if any
+func (s AlertStyle) withMention(issues []models.SecurityIssue, text string) string {
+	if mention := s.mention(issues); mention != "" {
+		return mention + " " + text
+	}
+	return text
+}
+
+// maxBlocks returns the block budget of a single alert message
+func (s AlertStyle) maxBlocks() int {
+	if s.MaxBlocks <= 0 || s.MaxBlocks > maxMessageBlocks {
+		return maxMessageBlocks
+	}
+	return s.MaxBlocks
+}
+
+// withEmoji prefixes text with emoji unless emoji are disabled
+func (s AlertStyle) withEmoji(emoji, text string) string {
+	if s.DisableEmoji || emoji == "" {
+		return text
+	}
+	return emoji + " " + text
+}
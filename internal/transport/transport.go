@@ -0,0 +1,55 @@
+// Package transport builds the pooled HTTP transport shared by all
+// outbound clients, so GitHub, Slack, AI, verification and secret manager
+// calls use the same proxy settings
+package transport
+
+import (
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/http/httpproxy"
+)
+
+// New creates a pooled transport that routes requests through proxy, or
+// through the standard proxy environment variables when proxy is empty.
+// Hosts in noProxy (default NO_PROXY) bypass proxy.
+func New(proxy string, noProxy []string) *http.Transport {
+	return &http.Transport{
+		Proxy: proxyFunc(proxy, noProxy),
+		DialContext: (&net.Dialer{
+			Timeout:   10 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		TLSHandshakeTimeout:   10 * time.Second,
+		ExpectContinueTimeout: 1 * time.Second,
+		IdleConnTimeout:       90 * time.Second,
+		MaxIdleConns:          100,
+		MaxIdleConnsPerHost:   10,
+		ForceAttemptHTTP2:     true,
+	}
+}
+
+// proxyFunc returns the proxy selection for outbound requests: proxy, or
+// the standard proxy environment variables when it is empty
+func proxyFunc(proxy string, noProxy []string) func(*http.Request) (*url.URL, error) {
+	if proxy == "" {
+		return http.ProxyFromEnvironment
+	}
+
+	bypass := strings.Join(noProxy, ",")
+	if len(noProxy) == 0 {
+		bypass = httpproxy.FromEnvironment().NoProxy
+	}
+	selectProxy := (&httpproxy.Config{
+		HTTPProxy:  proxy,
+		HTTPSProxy: proxy,
+		NoProxy:    bypass,
+	}).ProxyFunc()
+
+	return func(req *http.Request) (*url.URL, error) {
+		return selectProxy(req.URL)
+	}
+}
@@ -0,0 +1,103 @@
+package verify
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// GitHubVerifier checks GitHub tokens against the rate limit endpoint,
+// which any valid token can read without using up its quota
+type GitHubVerifier struct {
+	client *http.Client
+}
+
+// Verify checks a GitHub token
+func (v *GitHubVerifier) Verify(ctx context.Context, secret string) (Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.github.com/rate_limit", nil)
+	if err != nil {
+		return StatusUnverified, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+secret)
+	req.Header.Set("Accept", "application/vnd.github+json")
+
+	return statusFromResponse(v.client, req)
+}
+
+// SlackVerifier checks Slack tokens with auth.test
+type SlackVerifier struct {
+	client *http.Client
+}
+
+// Verify checks a Slack token
+func (v *SlackVerifier) Verify(ctx context.Context, secret string) (Status, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, "https://slack.com/api/auth.test", nil)
+	if err != nil {
+		return StatusUnverified, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+secret)
+
+	resp, err := v.client.Do(req)
+	if err != nil {
+		return StatusUnverified, fmt.Errorf("auth.test request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return StatusUnverified, fmt.Errorf("failed to decode auth.test response: %w", err)
+	}
+
+	switch {
+	case result.OK:
+		return StatusActive, nil
+	case result.Error == "invalid_auth" || result.Error == "token_revoked" ||
+		result.Error == "account_inactive" || result.Error == "not_authed":
+		return StatusInactive, nil
+	}
+	return StatusUnverified, fmt.Errorf("unexpected auth.test error %q", result.Error)
+}
+
+// StripeVerifier checks Stripe secret keys against the balance endpoint.
+// Publishable keys are public by design and are not checked.
+type StripeVerifier struct {
+	client *http.Client
+}
+
+// Verify checks a Stripe secret key
+func (v *StripeVerifier) Verify(ctx context.Context, secret string) (Status, error) {
+	if !strings.HasPrefix(secret, "sk_") {
+		return StatusUnverified, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, "https://api.stripe.com/v1/balance", nil)
+	if err != nil {
+		return StatusUnverified, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(secret, "")
+
+	return statusFromResponse(v.client, req)
+}
+
+// statusFromResponse maps the HTTP status of an authenticated request to a
+// verification status: 2xx is active and 401 is inactive
+func statusFromResponse(client *http.Client, req *http.Request) (Status, error) {
+	resp, err := client.Do(req)
+	if err != nil {
+		return StatusUnverified, fmt.Errorf("request to %s failed: %w", req.URL.Host, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode >= 200 && resp.StatusCode < 300:
+		return StatusActive, nil
+	case resp.StatusCode == http.StatusUnauthorized:
+		return StatusInactive, nil
+	}
+	return StatusUnverified, fmt.Errorf("unexpected status %d from %s", resp.StatusCode, req.URL.Host)
+}
@@ -0,0 +1,91 @@
+package verify
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// Status is the outcome of verifying a secret
+type Status string
+
+const (
+	// StatusUnverified means the secret was not or could not be checked
+	StatusUnverified Status = ""
+	// StatusActive means the provider accepted the secret
+	StatusActive Status = "active"
+	// StatusInactive means the provider rejected the secret, so it is
+	// likely revoked or a false positive
+	StatusInactive Status = "inactive"
+)
+
+// verifyTimeout bounds a single provider API call
+const verifyTimeout = 5 * time.Second
+
+// Verifier checks a secret against its provider with a read-only API call.
+// Implementations must never include the secret in errors or logs.
+type Verifier interface {
+	Verify(ctx context.Context, secret string) (Status, error)
+}
+
+// Registry dispatches secrets to the verifier for their pattern and rate
+// limits the calls made to providers
+type Registry struct {
+	verifiers map[string]Verifier
+	limiter   *rate.Limiter
+}
+
+// NewRegistry creates a registry with the built-in verifiers, allowing at
+// most requestsPerMinute provider calls. Calls use transport; nil uses
+// http.DefaultTransport.
+func NewRegistry(requestsPerMinute int, transport http.RoundTripper) *Registry {
+	client := &http.Client{Transport: transport, Timeout: verifyTimeout}
+
+	github := &GitHubVerifier{client: client}
+	slack := &SlackVerifier{client: client}
+	return &Registry{
+		verifiers: map[string]Verifier{
+			"GitHub Personal Access Token": github,
+			"GitHub OAuth Token":           github,
+			"GitHub App Token":             github,
+			"Slack Bot Token":              slack,
+			"Slack User Token":             slack,
+			"Slack Legacy Token":           slack,
+			"Stripe API Key":               &StripeVerifier{client: client},
+		},
+		limiter: rate.NewLimiter(rate.Limit(float64(requestsPerMinute)/60), 1),
+	}
+}
+
+// Register sets the verifier used for a pattern
+func (r *Registry) Register(pattern string, v Verifier) {
+	r.verifiers[pattern] = v
+}
+
+// Verify checks secret with the verifier registered for pattern. Secrets
+// without a verifier, or checked while the rate limit is exhausted, are
+// left unverified rather than delaying the scan.
+func (r *Registry) Verify(pattern, secret string) Status {
+	if r == nil {
+		return StatusUnverified
+	}
+
+	v, ok := r.verifiers[pattern]
+	if !ok || !r.limiter.Allow() {
+		return StatusUnverified
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), verifyTimeout)
+	defer cancel()
+
+	status, err := v.Verify(ctx, secret)
+	if err != nil {
+		// Verifier errors never contain the secret, so they are safe to log
+		log.Printf("Could not verify %s: %v", pattern, err)
+		return StatusUnverified
+	}
+	return status
+}